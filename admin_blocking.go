@@ -0,0 +1,237 @@
+package wso2am
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+)
+
+type (
+	// BlockingCondition is a deny (blacklist) policy.
+	// The gateway rejects the requests which match the enabled conditions immediately.
+	// https://github.com/wso2/carbon-apimgt/blob/master/components/apimgt/org.wso2.carbon.apimgt.rest.api.admin/src/gen/java/org/wso2/carbon/apimgt/rest/api/admin/dto/BlockingConditionDTO.java
+	BlockingCondition struct {
+		ID   string                `json:"conditionId,omitempty"`
+		Type BlockingConditionType `json:"conditionType"`
+		// Value is the string for API, APPLICATION and USER, and the IPConditionValue or the IPRangeConditionValue
+		// for IP and IPRANGE.  The values returned by the server are decoded as string or map[string]interface{}.
+		// The admin API v0.x has the string value only, so the IP values are sent as the JSON-encoded strings.
+		Value   interface{} `json:"conditionValue"`
+		Enabled bool        `json:"conditionStatus"`
+	}
+	// IPConditionValue is the value of the IP condition.
+	IPConditionValue struct {
+		FixedIP string `json:"fixedIp"`
+		Invert  bool   `json:"invert"`
+	}
+	// IPRangeConditionValue is the value of the IPRANGE condition.
+	IPRangeConditionValue struct {
+		StartingIP string `json:"startingIp"`
+		EndingIP   string `json:"endingIp"`
+		Invert     bool   `json:"invert"`
+	}
+	BlockingConditionType string
+)
+
+const (
+	// BlockingConditionTypeAPI blocks the API of the context (e.g. /pizzashack/1.0.0).
+	BlockingConditionTypeAPI BlockingConditionType = "API"
+	// BlockingConditionTypeApplication blocks the application of OWNER:NAME.
+	BlockingConditionTypeApplication BlockingConditionType = "APPLICATION"
+	BlockingConditionTypeIP          BlockingConditionType = "IP"
+	BlockingConditionTypeIPRange     BlockingConditionType = "IPRANGE"
+	BlockingConditionTypeUser        BlockingConditionType = "USER"
+)
+
+// BlockingConditionTypes are the available condition types.
+var BlockingConditionTypes = []BlockingConditionType{BlockingConditionTypeAPI, BlockingConditionTypeApplication, BlockingConditionTypeIP, BlockingConditionTypeIPRange, BlockingConditionTypeUser}
+
+// ParseBlockingConditionType parses the condition type case-insensitively.
+func ParseBlockingConditionType(s string) (BlockingConditionType, error) {
+	names := []string{}
+	for _, t := range BlockingConditionTypes {
+		if strings.EqualFold(string(t), s) {
+			return t, nil
+		}
+		names = append(names, string(t))
+	}
+	return "", fmt.Errorf("unsupported condition type: %s (available: %s)", s, strings.Join(names, ", "))
+}
+
+// NewBlockingCondition creates the enabled condition after validating the value locally.
+// The IP range is START-END or CIDR (e.g. 192.168.0.0/24).
+// Invert blocks the requests from outside of the IP or the IP range, and is available only for them.
+func NewBlockingCondition(t BlockingConditionType, value string, invert bool) (*BlockingCondition, error) {
+	if invert && t != BlockingConditionTypeIP && t != BlockingConditionTypeIPRange {
+		return nil, fmt.Errorf("invert is not available for the %s condition", t)
+	}
+	condition := &BlockingCondition{
+		Type:    t,
+		Enabled: true,
+	}
+	switch t {
+	case BlockingConditionTypeAPI:
+		if !strings.HasPrefix(value, "/") {
+			return nil, fmt.Errorf("API context must start with '/': %q", value)
+		}
+		condition.Value = value
+	case BlockingConditionTypeApplication:
+		if i := strings.Index(value, ":"); i <= 0 || i == len(value)-1 {
+			return nil, fmt.Errorf("application must be OWNER:NAME: %q", value)
+		}
+		condition.Value = value
+	case BlockingConditionTypeUser:
+		if value == "" {
+			return nil, errors.New("user name is required")
+		}
+		condition.Value = value
+	case BlockingConditionTypeIP:
+		ip := net.ParseIP(value)
+		if ip == nil {
+			return nil, fmt.Errorf("invalid IP address: %q", value)
+		}
+		condition.Value = &IPConditionValue{
+			FixedIP: ip.String(),
+			Invert:  invert,
+		}
+	case BlockingConditionTypeIPRange:
+		start, end, err := ParseIPRange(value)
+		if err != nil {
+			return nil, err
+		}
+		condition.Value = &IPRangeConditionValue{
+			StartingIP: start.String(),
+			EndingIP:   end.String(),
+			Invert:     invert,
+		}
+	default:
+		return nil, fmt.Errorf("unsupported condition type: %s", t)
+	}
+	return condition, nil
+}
+
+// ParseIPRange parses the IP range of START-END or CIDR, and returns the first and the last addresses.
+func ParseIPRange(s string) (start, end net.IP, err error) {
+	if strings.Contains(s, "/") {
+		_, network, err := net.ParseCIDR(s)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid CIDR: %q", s)
+		}
+		start = network.IP
+		end = make(net.IP, len(start))
+		for i := range start {
+			end[i] = start[i] | ^network.Mask[i]
+		}
+		return start, end, nil
+	}
+	i := strings.Index(s, "-")
+	if i < 0 {
+		return nil, nil, fmt.Errorf("invalid IP range: %q (expected START-END or CIDR)", s)
+	}
+	start = net.ParseIP(strings.TrimSpace(s[:i]))
+	end = net.ParseIP(strings.TrimSpace(s[i+1:]))
+	if start == nil || end == nil {
+		return nil, nil, fmt.Errorf("invalid IP range: %q", s)
+	}
+	if (start.To4() == nil) != (end.To4() == nil) {
+		return nil, nil, fmt.Errorf("IP range mixes IPv4 and IPv6: %q", s)
+	}
+	if bytes.Compare(start.To16(), end.To16()) > 0 {
+		return nil, nil, fmt.Errorf("start of the IP range is after the end: %q", s)
+	}
+	return start, end, nil
+}
+
+// ValueString returns the value of the condition in the form accepted by NewBlockingCondition.
+// The inverted IP conditions are prefixed with "!".
+func (b *BlockingCondition) ValueString() string {
+	var value string
+	var invert bool
+	switch v := b.Value.(type) {
+	case string:
+		// the IP values of the admin API v0.x are the JSON-encoded strings
+		var m map[string]interface{}
+		if strings.HasPrefix(v, "{") && json.Unmarshal([]byte(v), &m) == nil {
+			return (&BlockingCondition{Value: m}).ValueString()
+		}
+		return v
+	case *IPConditionValue:
+		value, invert = v.FixedIP, v.Invert
+	case *IPRangeConditionValue:
+		value, invert = v.StartingIP+"-"+v.EndingIP, v.Invert
+	case map[string]interface{}:
+		if ip, ok := v["fixedIp"]; ok {
+			value = fmt.Sprint(ip)
+		} else {
+			value = fmt.Sprintf("%v-%v", v["startingIp"], v["endingIp"])
+		}
+		invert, _ = v["invert"].(bool)
+	default:
+		return fmt.Sprint(v)
+	}
+	if invert {
+		return "!" + value
+	}
+	return value
+}
+
+// BlockingConditions returns the deny policies.
+func (c *Client) BlockingConditions() ([]BlockingCondition, error) {
+	var v struct {
+		Count int                 `json:"count"`
+		List  []BlockingCondition `json:"list"`
+	}
+	if err := c.get(c.adminURL("throttling/blacklist"), "apim:bl_view", &v); err != nil {
+		return nil, err
+	}
+	return v.List, nil
+}
+
+// BlockingCondition returns the deny policy of the ID.
+func (c *Client) BlockingCondition(id string) (*BlockingCondition, error) {
+	var v BlockingCondition
+	if err := c.get(c.adminURL("throttling/blacklist/"+url.PathEscape(id)), "apim:bl_view", &v); err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
+
+// CreateBlockingCondition creates the deny policy.
+func (c *Client) CreateBlockingCondition(condition *BlockingCondition) (*BlockingCondition, error) {
+	if _, ok := condition.Value.(string); !ok && strings.HasPrefix(c.config.APIVersion, "v0.") {
+		value, err := json.Marshal(condition.Value)
+		if err != nil {
+			return nil, err
+		}
+		encoded := *condition
+		encoded.Value = string(value)
+		condition = &encoded
+	}
+	var v BlockingCondition
+	if err := c.post(c.adminURL("throttling/blacklist"), "apim:bl_manage", newJSONRequestBody(condition), &v); err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
+
+// SetBlockingConditionEnabled enables or disables the deny policy.
+func (c *Client) SetBlockingConditionEnabled(id string, enabled bool) (*BlockingCondition, error) {
+	body := map[string]interface{}{
+		"conditionId":     id,
+		"conditionStatus": enabled,
+	}
+	var v BlockingCondition
+	if err := c.patch(c.adminURL("throttling/blacklist/"+url.PathEscape(id)), "apim:bl_manage", newJSONRequestBody(body), &v); err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
+
+// DeleteBlockingCondition deletes the deny policy.
+func (c *Client) DeleteBlockingCondition(id string) error {
+	return c.delete(c.adminURL("throttling/blacklist/"+url.PathEscape(id)), "apim:bl_manage", nil)
+}
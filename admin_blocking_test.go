@@ -0,0 +1,26 @@
+package wso2am
+
+import "testing"
+
+func TestBlockingConditionValueString(t *testing.T) {
+	tests := []struct {
+		name  string
+		value interface{}
+		want  string
+	}{
+		{name: "string", value: "/pizzashack/1.0.0", want: "/pizzashack/1.0.0"},
+		{name: "IP", value: &IPConditionValue{FixedIP: "192.168.0.1"}, want: "192.168.0.1"},
+		{name: "inverted IP range", value: &IPRangeConditionValue{StartingIP: "10.0.0.0", EndingIP: "10.0.0.255", Invert: true}, want: "!10.0.0.0-10.0.0.255"},
+		{name: "IP of admin API v1", value: map[string]interface{}{"fixedIp": "192.168.0.1", "invert": true}, want: "!192.168.0.1"},
+		{name: "IP of admin API v0.x", value: `{"fixedIp":"192.168.0.1","invert":false}`, want: "192.168.0.1"},
+		{name: "IP range of admin API v0.x", value: `{"startingIp":"10.0.0.0","endingIp":"10.0.0.255","invert":true}`, want: "!10.0.0.0-10.0.0.255"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := &BlockingCondition{Value: tt.value}
+			if got := b.ValueString(); got != tt.want {
+				t.Errorf("ValueString() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
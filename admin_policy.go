@@ -0,0 +1,368 @@
+package wso2am
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/url"
+	"strings"
+
+	yaml "gopkg.in/yaml.v2"
+)
+
+type (
+	// ThrottlePolicy is a rate limit policy managed with the admin API.
+	// The fields used depend on the level: DefaultLimit is for all the levels except custom,
+	// ConditionalGroups for advanced, the rate limit and the billing fields for subscription,
+	// and SiddhiQuery and KeyTemplate for custom.
+	// https://github.com/wso2/carbon-apimgt/tree/master/components/apimgt/org.wso2.carbon.apimgt.rest.api.admin/src/gen/java/org/wso2/carbon/apimgt/rest/api/admin/dto
+	ThrottlePolicy struct {
+		// Level is not a part of the API, it tells the resource of the policy.
+		Level             PolicyLevel        `json:"-"`
+		ID                string             `json:"policyId,omitempty"`
+		Name              string             `json:"policyName"`
+		DisplayName       string             `json:"displayName,omitempty"`
+		Description       string             `json:"description,omitempty"`
+		Deployed          bool               `json:"isDeployed"`
+		DefaultLimit      *ThrottleLimit     `json:"defaultLimit,omitempty"`
+		ConditionalGroups []ConditionalGroup `json:"conditionalGroups,omitempty"`
+		RateLimitCount    int                `json:"rateLimitCount,omitempty"`
+		RateLimitTimeUnit string             `json:"rateLimitTimeUnit,omitempty"`
+		CustomAttributes  []CustomAttribute  `json:"customAttributes,omitempty"`
+		StopOnQuotaReach  bool               `json:"stopOnQuotaReach,omitempty"`
+		BillingPlan       string             `json:"billingPlan,omitempty"`
+		SiddhiQuery       string             `json:"siddhiQuery,omitempty"`
+		KeyTemplate       string             `json:"keyTemplate,omitempty"`
+	}
+	// ThrottleLimit is the quota of the policy.
+	// RequestCount is for the request count limit, and DataAmount and DataUnit are for the bandwidth limit.
+	ThrottleLimit struct {
+		Type         string `json:"type"`
+		TimeUnit     string `json:"timeUnit"`
+		UnitTime     int    `json:"unitTime"`
+		RequestCount int64  `json:"requestCount,omitempty"`
+		DataAmount   int64  `json:"dataAmount,omitempty"`
+		DataUnit     string `json:"dataUnit,omitempty"`
+	}
+	// ConditionalGroup is the limit applied to the requests which match all the conditions.
+	ConditionalGroup struct {
+		Description string              `json:"description,omitempty"`
+		Conditions  []ThrottleCondition `json:"conditions"`
+		Limit       ThrottleLimit       `json:"limit"`
+	}
+	// ThrottleCondition is a condition of the conditional group.  The fields used depend on the type.
+	ThrottleCondition struct {
+		Type            string `json:"type"`
+		InvertCondition bool   `json:"invertCondition"`
+		// HeaderCondition
+		HeaderName  string `json:"headerName,omitempty"`
+		HeaderValue string `json:"headerValue,omitempty"`
+		// IPCondition
+		IPConditionType string `json:"ipConditionType,omitempty"`
+		SpecificIP      string `json:"specificIP,omitempty"`
+		StartingIP      string `json:"startingIP,omitempty"`
+		EndingIP        string `json:"endingIP,omitempty"`
+		// QueryParameterCondition
+		ParameterName  string `json:"parameterName,omitempty"`
+		ParameterValue string `json:"parameterValue,omitempty"`
+		// JWTClaimsCondition
+		ClaimURL  string `json:"claimUrl,omitempty"`
+		Attribute string `json:"attribute,omitempty"`
+	}
+	// CustomAttribute is a custom attribute of the subscription policy.
+	CustomAttribute struct {
+		Name  string `json:"name"`
+		Value string `json:"value"`
+	}
+	PolicyLevel string
+)
+
+const (
+	// PolicyLevelAdvanced is the level of the API and the resource level policies.
+	PolicyLevelAdvanced     PolicyLevel = "advanced"
+	PolicyLevelApplication  PolicyLevel = "application"
+	PolicyLevelSubscription PolicyLevel = "subscription"
+	// PolicyLevelCustom is the level of the custom policies written in Siddhi.
+	PolicyLevelCustom PolicyLevel = "custom"
+)
+
+const (
+	ThrottleLimitTypeRequestCount = "RequestCountLimit"
+	ThrottleLimitTypeBandwidth    = "BandwidthLimit"
+)
+
+const (
+	ThrottleConditionTypeIP             = "IPCondition"
+	ThrottleConditionTypeHeader         = "HeaderCondition"
+	ThrottleConditionTypeQueryParameter = "QueryParameterCondition"
+	ThrottleConditionTypeJWTClaims      = "JWTClaimsCondition"
+
+	IPConditionTypeSpecific = "IPSpecific"
+	IPConditionTypeRange    = "IPRange"
+)
+
+// PolicyLevels are the available policy levels.
+var PolicyLevels = []PolicyLevel{PolicyLevelAdvanced, PolicyLevelApplication, PolicyLevelSubscription, PolicyLevelCustom}
+
+// ParsePolicyLevel parses the policy level case-insensitively.
+func ParsePolicyLevel(s string) (PolicyLevel, error) {
+	names := []string{}
+	for _, l := range PolicyLevels {
+		if strings.EqualFold(string(l), s) {
+			return l, nil
+		}
+		names = append(names, string(l))
+	}
+	return "", fmt.Errorf("unsupported policy level: %s (available: %s)", s, strings.Join(names, ", "))
+}
+
+func (c *Client) policyURL(level PolicyLevel, id string) string {
+	path := "throttling/policies/" + url.PathEscape(string(level))
+	if id != "" {
+		path += "/" + url.PathEscape(id)
+	}
+	return c.adminURL(path)
+}
+
+// ThrottlePolicies returns the policies of the level.
+func (c *Client) ThrottlePolicies(level PolicyLevel) ([]ThrottlePolicy, error) {
+	var v struct {
+		Count int              `json:"count"`
+		List  []ThrottlePolicy `json:"list"`
+	}
+	if err := c.get(c.policyURL(level, ""), "apim:tier_view", &v); err != nil {
+		return nil, err
+	}
+	for i := range v.List {
+		v.List[i].Level = level
+	}
+	return v.List, nil
+}
+
+// ThrottlePolicy returns the policy of the ID.
+func (c *Client) ThrottlePolicy(level PolicyLevel, id string) (*ThrottlePolicy, error) {
+	var v ThrottlePolicy
+	if err := c.get(c.policyURL(level, id), "apim:tier_view", &v); err != nil {
+		return nil, err
+	}
+	v.Level = level
+	return &v, nil
+}
+
+// FindThrottlePolicy finds the policy of the level by the name.
+// It returns nil if not found.
+func (c *Client) FindThrottlePolicy(level PolicyLevel, name string) (*ThrottlePolicy, error) {
+	policies, err := c.ThrottlePolicies(level)
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range policies {
+		if p.Name == name {
+			return c.ThrottlePolicy(level, p.ID)
+		}
+	}
+	return nil, nil
+}
+
+// CreateThrottlePolicy creates the policy.
+func (c *Client) CreateThrottlePolicy(policy *ThrottlePolicy) (*ThrottlePolicy, error) {
+	if err := policy.Validate(); err != nil {
+		return nil, err
+	}
+	var v ThrottlePolicy
+	if err := c.post(c.policyURL(policy.Level, ""), "apim:tier_manage", newJSONRequestBody(policy), &v); err != nil {
+		return nil, err
+	}
+	v.Level = policy.Level
+	return &v, nil
+}
+
+// UpdateThrottlePolicy updates the policy of the ID.
+func (c *Client) UpdateThrottlePolicy(policy *ThrottlePolicy) (*ThrottlePolicy, error) {
+	if policy.ID == "" {
+		return nil, fmt.Errorf("ID of the policy %s is required", policy.Name)
+	}
+	if err := policy.Validate(); err != nil {
+		return nil, err
+	}
+	var v ThrottlePolicy
+	if err := c.put(c.policyURL(policy.Level, policy.ID), "apim:tier_manage", newJSONRequestBody(policy), &v); err != nil {
+		return nil, err
+	}
+	v.Level = policy.Level
+	return &v, nil
+}
+
+// DeleteThrottlePolicy deletes the policy of the ID.
+func (c *Client) DeleteThrottlePolicy(level PolicyLevel, id string) error {
+	return c.delete(c.policyURL(level, id), "apim:tier_manage", nil)
+}
+
+// ApplyThrottlePolicy creates the policy, or updates the policy of the same name if exists.
+func (c *Client) ApplyThrottlePolicy(policy *ThrottlePolicy) (result *ThrottlePolicy, created bool, err error) {
+	if err := policy.Validate(); err != nil {
+		return nil, false, err
+	}
+	current, err := c.FindThrottlePolicy(policy.Level, policy.Name)
+	if err != nil {
+		return nil, false, err
+	}
+	if current == nil {
+		result, err = c.CreateThrottlePolicy(policy)
+		return result, true, err
+	}
+	p := *policy
+	p.ID = current.ID
+	result, err = c.UpdateThrottlePolicy(&p)
+	return result, false, err
+}
+
+// ReadThrottlePolicyFile reads the policy from the YAML or JSON file.
+// The file has the fields of the API, and "level" for the level of the policy.
+// The level is left empty if the file does not have it.
+func ReadThrottlePolicyFile(path string) (*ThrottlePolicy, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var v map[string]interface{}
+	if err := yaml.Unmarshal(data, &v); err != nil {
+		return nil, fmt.Errorf("failed to parse the policy file %s: %v", path, err)
+	}
+	m := asMap(normalizeYAML(v))
+	var policy ThrottlePolicy
+	if level, ok := m["level"]; ok {
+		l, err := ParsePolicyLevel(fmt.Sprint(level))
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", path, err)
+		}
+		policy.Level = l
+		delete(m, "level")
+	}
+	if err := convert(m, &policy); err != nil {
+		return nil, fmt.Errorf("%s: %v", path, err)
+	}
+	return &policy, nil
+}
+
+// Validate checks the policy has the fields required by the level.
+func (p *ThrottlePolicy) Validate() error {
+	if p.Level == "" {
+		return errors.New("level of the policy is required")
+	}
+	if _, err := ParsePolicyLevel(string(p.Level)); err != nil {
+		return err
+	}
+	if p.Name == "" {
+		return errors.New("name of the policy is required")
+	}
+	if p.Level == PolicyLevelCustom {
+		if p.SiddhiQuery == "" || p.KeyTemplate == "" {
+			return fmt.Errorf("policy %s: siddhiQuery and keyTemplate are required for the custom policy", p.Name)
+		}
+		return nil
+	}
+	if p.DefaultLimit == nil {
+		return fmt.Errorf("policy %s: defaultLimit is required", p.Name)
+	}
+	if err := p.DefaultLimit.Validate(); err != nil {
+		return fmt.Errorf("policy %s: defaultLimit: %v", p.Name, err)
+	}
+	if len(p.ConditionalGroups) > 0 && p.Level != PolicyLevelAdvanced {
+		return fmt.Errorf("policy %s: conditionalGroups are available only for the advanced policy", p.Name)
+	}
+	for i, g := range p.ConditionalGroups {
+		if err := g.Validate(); err != nil {
+			return fmt.Errorf("policy %s: conditionalGroups[%d]: %v", p.Name, i, err)
+		}
+	}
+	return nil
+}
+
+// Validate checks the limit has the fields required by the type.
+func (l *ThrottleLimit) Validate() error {
+	if l.TimeUnit == "" || l.UnitTime <= 0 {
+		return errors.New("timeUnit and positive unitTime are required")
+	}
+	switch l.Type {
+	case ThrottleLimitTypeRequestCount:
+		if l.RequestCount <= 0 {
+			return errors.New("positive requestCount is required for " + l.Type)
+		}
+	case ThrottleLimitTypeBandwidth:
+		if l.DataAmount <= 0 || l.DataUnit == "" {
+			return errors.New("positive dataAmount and dataUnit are required for " + l.Type)
+		}
+	default:
+		return fmt.Errorf("unsupported limit type: %q (available: %s, %s)", l.Type, ThrottleLimitTypeRequestCount, ThrottleLimitTypeBandwidth)
+	}
+	return nil
+}
+
+// Validate checks the group has the valid conditions and limit.
+func (g *ConditionalGroup) Validate() error {
+	if len(g.Conditions) == 0 {
+		return errors.New("conditions are required")
+	}
+	for i, c := range g.Conditions {
+		if err := c.Validate(); err != nil {
+			return fmt.Errorf("conditions[%d]: %v", i, err)
+		}
+	}
+	if err := g.Limit.Validate(); err != nil {
+		return fmt.Errorf("limit: %v", err)
+	}
+	return nil
+}
+
+// Validate checks the condition has the fields required by the type.
+func (c *ThrottleCondition) Validate() error {
+	switch c.Type {
+	case ThrottleConditionTypeHeader:
+		if c.HeaderName == "" || c.HeaderValue == "" {
+			return errors.New("headerName and headerValue are required for " + c.Type)
+		}
+	case ThrottleConditionTypeQueryParameter:
+		if c.ParameterName == "" || c.ParameterValue == "" {
+			return errors.New("parameterName and parameterValue are required for " + c.Type)
+		}
+	case ThrottleConditionTypeJWTClaims:
+		if c.ClaimURL == "" || c.Attribute == "" {
+			return errors.New("claimUrl and attribute are required for " + c.Type)
+		}
+	case ThrottleConditionTypeIP:
+		switch c.IPConditionType {
+		case IPConditionTypeSpecific:
+			if net.ParseIP(c.SpecificIP) == nil {
+				return fmt.Errorf("invalid specificIP: %q", c.SpecificIP)
+			}
+		case IPConditionTypeRange:
+			if net.ParseIP(c.StartingIP) == nil || net.ParseIP(c.EndingIP) == nil {
+				return fmt.Errorf("invalid IP range: %q - %q", c.StartingIP, c.EndingIP)
+			}
+		default:
+			return fmt.Errorf("unsupported ipConditionType: %q (available: %s, %s)", c.IPConditionType, IPConditionTypeSpecific, IPConditionTypeRange)
+		}
+	default:
+		return fmt.Errorf("unsupported condition type: %q (available: %s)", c.Type, strings.Join([]string{ThrottleConditionTypeIP, ThrottleConditionTypeHeader, ThrottleConditionTypeQueryParameter, ThrottleConditionTypeJWTClaims}, ", "))
+	}
+	return nil
+}
+
+// Limit returns the summary of the default limit like "100 requests/1 min".
+func (p *ThrottlePolicy) Limit() string {
+	if p.DefaultLimit == nil {
+		return ""
+	}
+	return p.DefaultLimit.String()
+}
+
+func (l *ThrottleLimit) String() string {
+	switch l.Type {
+	case ThrottleLimitTypeBandwidth:
+		return fmt.Sprintf("%d %s/%d %s", l.DataAmount, l.DataUnit, l.UnitTime, l.TimeUnit)
+	default:
+		return fmt.Sprintf("%d requests/%d %s", l.RequestCount, l.UnitTime, l.TimeUnit)
+	}
+}
@@ -0,0 +1,214 @@
+package wso2am
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+type (
+	// Workflow is a pending approval of the workflow.
+	// https://github.com/wso2/carbon-apimgt/blob/master/components/apimgt/org.wso2.carbon.apimgt.rest.api.admin/src/gen/java/org/wso2/carbon/apimgt/rest/api/admin/dto/WorkflowInfoDTO.java
+	Workflow struct {
+		Type        WorkflowType `json:"workflowType"`
+		Status      string       `json:"workflowStatus"`
+		CreatedTime string       `json:"createdTime"`
+		UpdatedTime string       `json:"updatedTime"`
+		ReferenceID string       `json:"referenceId"`
+		// Properties are the details of the request, e.g. applicationName, apiName and tier of the subscription.
+		Properties  map[string]interface{} `json:"properties"`
+		Description string                 `json:"description"`
+	}
+	// WorkflowFilter selects the workflows.  The empty fields match any workflow.
+	WorkflowFilter struct {
+		Type WorkflowType
+		// Properties are compared with the properties of the workflows case-insensitively.
+		Properties map[string]string
+	}
+	WorkflowType string
+)
+
+const (
+	WorkflowTypeApplicationCreation               WorkflowType = "AM_APPLICATION_CREATION"
+	WorkflowTypeSubscriptionCreation              WorkflowType = "AM_SUBSCRIPTION_CREATION"
+	WorkflowTypeUserSignup                        WorkflowType = "AM_USER_SIGNUP"
+	WorkflowTypeApplicationRegistrationProduction WorkflowType = "AM_APPLICATION_REGISTRATION_PRODUCTION"
+	WorkflowTypeApplicationRegistrationSandbox    WorkflowType = "AM_APPLICATION_REGISTRATION_SANDBOX"
+	WorkflowTypeAPIState                          WorkflowType = "AM_API_STATE"
+)
+
+const (
+	WorkflowStatusApproved = "APPROVED"
+	WorkflowStatusRejected = "REJECTED"
+)
+
+// workflowTypeNames are the short names of the workflow types.
+var workflowTypeNames = map[string]WorkflowType{
+	"application":             WorkflowTypeApplicationCreation,
+	"subscription":            WorkflowTypeSubscriptionCreation,
+	"signup":                  WorkflowTypeUserSignup,
+	"registration-production": WorkflowTypeApplicationRegistrationProduction,
+	"registration-sandbox":    WorkflowTypeApplicationRegistrationSandbox,
+	"api-state":               WorkflowTypeAPIState,
+}
+
+// WorkflowTypeNames returns the short names of the workflow types in order.
+func WorkflowTypeNames() []string {
+	names := []string{}
+	for name := range workflowTypeNames {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ParseWorkflowType parses the short name (e.g. subscription) or the name (e.g. AM_SUBSCRIPTION_CREATION) of the workflow type.
+func ParseWorkflowType(s string) (WorkflowType, error) {
+	if t, ok := workflowTypeNames[strings.ToLower(s)]; ok {
+		return t, nil
+	}
+	for _, t := range workflowTypeNames {
+		if strings.EqualFold(string(t), s) {
+			return t, nil
+		}
+	}
+	return "", fmt.Errorf("unsupported workflow type: %s (available: %s)", s, strings.Join(WorkflowTypeNames(), ", "))
+}
+
+// PendingWorkflows returns the workflows waiting for the approval.
+// All the types are returned if the type is empty.
+// The workflows are listed by the admin API v1 or later.
+func (c *Client) PendingWorkflows(t WorkflowType) ([]Workflow, error) {
+	if err := c.checkWorkflowListSupported(); err != nil {
+		return nil, err
+	}
+	result, err := c.SearchResultToSlice(func(entryc chan<- interface{}, errc chan<- error, done <-chan struct{}) {
+		c.search(entryc, errc, done, func(q *PageQuery) (*PageResponse, error) {
+			params := pageQueryParams(q)
+			if t != "" {
+				params.Add("workflowType", string(t))
+			}
+			var v PageResponse
+			if err := c.get(c.adminURL("workflows?"+params.Encode()), "apim:api_workflow", &v); err != nil {
+				return nil, err
+			}
+			return &v, nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	var workflows []Workflow
+	if err := convert(result, &workflows); err != nil {
+		return nil, err
+	}
+	return workflows, nil
+}
+
+// Workflow returns the pending workflow of the reference ID.
+// The workflows are looked up by the admin API v1 or later.
+func (c *Client) Workflow(referenceID string) (*Workflow, error) {
+	if err := c.checkWorkflowListSupported(); err != nil {
+		return nil, err
+	}
+	var v Workflow
+	if err := c.get(c.adminURL("workflows/"+url.PathEscape(referenceID)), "apim:api_workflow", &v); err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
+
+// ApproveWorkflow approves the workflow.  The description is shown to the requester.
+func (c *Client) ApproveWorkflow(referenceID, description string) error {
+	return c.updateWorkflowStatus(referenceID, WorkflowStatusApproved, description)
+}
+
+// RejectWorkflow rejects the workflow.  The description is shown to the requester.
+func (c *Client) RejectWorkflow(referenceID, description string) error {
+	return c.updateWorkflowStatus(referenceID, WorkflowStatusRejected, description)
+}
+
+func (c *Client) updateWorkflowStatus(referenceID, status, description string) error {
+	params := url.Values{}
+	params.Add("workflowReferenceId", referenceID)
+	body := map[string]interface{}{
+		"status":      status,
+		"description": description,
+		"attributes":  map[string]string{},
+	}
+	var v interface{}
+	return c.post(c.adminURL("workflows/update-workflow-status?"+params.Encode()), "apim:api_workflow", newJSONRequestBody(body), &v)
+}
+
+// checkWorkflowListSupported rejects the admin API v0.x, which can only update the status of the workflows.
+func (c *Client) checkWorkflowListSupported() error {
+	if strings.HasPrefix(c.config.APIVersion, "v0.") {
+		return fmt.Errorf("listing the workflows is not supported by the admin API %s; use v1 or later, or specify the reference IDs", c.config.APIVersion)
+	}
+	return nil
+}
+
+// Property returns the property of the workflow as string, or empty string if not exists.
+func (w *Workflow) Property(key string) string {
+	v, ok := w.Properties[key]
+	if !ok || v == nil {
+		return ""
+	}
+	return fmt.Sprint(v)
+}
+
+// PropertiesString returns the properties as "KEY=VALUE" sorted by the keys.
+func (w *Workflow) PropertiesString() string {
+	keys := []string{}
+	for k := range w.Properties {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	props := []string{}
+	for _, k := range keys {
+		props = append(props, k+"="+w.Property(k))
+	}
+	return strings.Join(props, ", ")
+}
+
+// SetProperty parses the "KEY=VALUE" expression and sets it to the filter.
+func (f *WorkflowFilter) SetProperty(expr string) error {
+	i := strings.Index(expr, "=")
+	if i <= 0 {
+		return fmt.Errorf("invalid property %q (expected KEY=VALUE)", expr)
+	}
+	if f.Properties == nil {
+		f.Properties = map[string]string{}
+	}
+	f.Properties[expr[:i]] = expr[i+1:]
+	return nil
+}
+
+// Match returns true if the workflow matches all the conditions.
+func (f *WorkflowFilter) Match(w *Workflow) bool {
+	if f.Type != "" && f.Type != w.Type {
+		return false
+	}
+	for k, v := range f.Properties {
+		if !strings.EqualFold(v, w.Property(k)) {
+			return false
+		}
+	}
+	return true
+}
+
+// FindPendingWorkflows returns the pending workflows which match the filter.
+func (c *Client) FindPendingWorkflows(filter *WorkflowFilter) ([]Workflow, error) {
+	workflows, err := c.PendingWorkflows(filter.Type)
+	if err != nil {
+		return nil, err
+	}
+	result := []Workflow{}
+	for i := range workflows {
+		if filter.Match(&workflows[i]) {
+			result = append(result, workflows[i])
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,422 @@
+package wso2am
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+)
+
+type (
+	// BackupManifest describes the contents of a backup directory.
+	BackupManifest struct {
+		FormatVersion int                 `json:"formatVersion"`
+		CreatedAt     time.Time           `json:"createdAt"`
+		EndpointURL   string              `json:"endpointUrl"`
+		APIVersion    string              `json:"apiVersion"`
+		APIs          []BackupManifestAPI `json:"apis"`
+	}
+	BackupManifestAPI struct {
+		ID       string    `json:"id"`
+		Name     string    `json:"name"`
+		Version  string    `json:"version"`
+		Provider string    `json:"provider"`
+		Context  string    `json:"context"`
+		Status   APIStatus `json:"status"`
+		// Path is the directory of the API relative to the backup directory.
+		Path string `json:"path"`
+	}
+	// RestoreReport is the result of Client.Restore.
+	RestoreReport struct {
+		// Restored is the list of the APIs restored by this run.
+		Restored []RestoreItem `json:"restored"`
+		// Skipped is the list of the APIs already restored by a previous run.
+		Skipped []RestoreItem `json:"skipped"`
+		// NotRecreated is the list of the items which cannot be recreated by the publisher API.
+		NotRecreated []RestoreItem `json:"notRecreated"`
+		// Failed is the list of the items failed to restore.  Run the restore again to retry them.
+		Failed []RestoreItem `json:"failed"`
+	}
+	RestoreItem struct {
+		API    string `json:"api"`
+		Item   string `json:"item"`
+		ID     string `json:"id,omitempty"`
+		Reason string `json:"reason,omitempty"`
+	}
+	restoreState struct {
+		APIs map[string]*restoreAPIState `json:"apis"`
+	}
+	restoreAPIState struct {
+		ID        string `json:"id"`
+		Thumbnail bool   `json:"thumbnail"`
+		Actions   int    `json:"actions"`
+		Completed bool   `json:"completed"`
+	}
+)
+
+const (
+	// BackupFormatVersion is the version of the backup directory layout.
+	BackupFormatVersion = 1
+
+	backupManifestFile      = "manifest.json"
+	backupRestoreStateFile  = "restore-state.json"
+	backupAPIFile           = "api.json"
+	backupDefinitionFile    = "swagger.json"
+	backupThumbnailFile     = "thumbnail"
+	backupSubscriptionsFile = "subscriptions.json"
+)
+
+var backupPathPattern = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// Backup writes all APIs of the publisher to the directory.
+//
+// The directory has the following layout:
+//
+//	manifest.json
+//	apis/PROVIDER_NAME_VERSION/api.json
+//	apis/PROVIDER_NAME_VERSION/swagger.json
+//	apis/PROVIDER_NAME_VERSION/thumbnail
+//	apis/PROVIDER_NAME_VERSION/subscriptions.json
+//
+// If the directories of two APIs collide after replacing the characters unsafe for the paths,
+// the ID of the API is appended to the directory (PROVIDER_NAME_VERSION_ID), which is recorded in the manifest.
+func (c *Client) Backup(dir string) (*BackupManifest, error) {
+	result, err := c.SearchResultToSlice(func(entryc chan<- interface{}, errc chan<- error, done <-chan struct{}) {
+		c.SearchAPIsRaw("", entryc, errc, done)
+	})
+	if err != nil {
+		return nil, err
+	}
+	manifest := &BackupManifest{
+		FormatVersion: BackupFormatVersion,
+		CreatedAt:     time.Now(),
+		EndpointURL:   c.config.EndpointCarbon,
+		APIVersion:    c.config.APIVersion,
+		APIs:          []BackupManifestAPI{},
+	}
+	// paths are compared case-insensitively for the case-insensitive file systems.
+	paths := map[string]bool{}
+	for _, v := range result {
+		a := c.ConvertToAPI(v)
+		path := filepath.Join("apis", backupPathPattern.ReplaceAllString(a.Provider+"_"+a.Name+"_"+a.Version, "_"))
+		if paths[strings.ToLower(path)] {
+			path = filepath.Join("apis", backupPathPattern.ReplaceAllString(a.Provider+"_"+a.Name+"_"+a.Version+"_"+a.ID, "_"))
+		}
+		paths[strings.ToLower(path)] = true
+		if err := c.backupAPI(a, filepath.Join(dir, path)); err != nil {
+			return nil, fmt.Errorf("failed to backup the API %s:%s: %v", a.Name, a.Version, err)
+		}
+		manifest.APIs = append(manifest.APIs, BackupManifestAPI{
+			ID:       a.ID,
+			Name:     a.Name,
+			Version:  a.Version,
+			Provider: a.Provider,
+			Context:  a.Context,
+			Status:   a.Status,
+			Path:     filepath.ToSlash(path),
+		})
+	}
+	if err := writeJSONFile(filepath.Join(dir, backupManifestFile), manifest); err != nil {
+		return nil, err
+	}
+	return manifest, nil
+}
+
+func (c *Client) backupAPI(a *API, dir string) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	detail, err := c.API(a.ID)
+	if err != nil {
+		return err
+	}
+	if err := writeJSONFile(filepath.Join(dir, backupAPIFile), detail); err != nil {
+		return err
+	}
+	def, err := c.APIDefinition(a.ID)
+	if err != nil {
+		return err
+	}
+	if err := writeJSONFile(filepath.Join(dir, backupDefinitionFile), def); err != nil {
+		return err
+	}
+	if detail.ThumbnailURI != "" {
+		buf := new(bytes.Buffer)
+		if err := c.Thumbnail(a.ID, buf); err != nil {
+			return err
+		}
+		if err := ioutil.WriteFile(filepath.Join(dir, backupThumbnailFile), buf.Bytes(), 0644); err != nil {
+			return err
+		}
+	}
+	subscriptions, err := c.SearchResultToSlice(func(entryc chan<- interface{}, errc chan<- error, done <-chan struct{}) {
+		c.SubscriptionsByAPIRaw(a.ID, entryc, errc, done)
+	})
+	if err != nil {
+		return err
+	}
+	s := []Subscription{}
+	for _, v := range subscriptions {
+		s = append(s, *c.ConvertToSubscription(v))
+	}
+	return writeJSONFile(filepath.Join(dir, backupSubscriptionsFile), s)
+}
+
+// Restore recreates the APIs in the backup directory.
+//
+// The progress is recorded in the backup directory, so calling Restore again after
+// failures resumes the restore from the failed step.
+func (c *Client) Restore(dir string) (*RestoreReport, error) {
+	var manifest BackupManifest
+	if err := readJSONFile(filepath.Join(dir, backupManifestFile), &manifest); err != nil {
+		return nil, err
+	}
+	if manifest.FormatVersion != BackupFormatVersion {
+		return nil, fmt.Errorf("unsupported backup format version: %d", manifest.FormatVersion)
+	}
+	statePath := filepath.Join(dir, backupRestoreStateFile)
+	state := &restoreState{APIs: map[string]*restoreAPIState{}}
+	if _, err := os.Stat(statePath); err == nil {
+		if err := readJSONFile(statePath, state); err != nil {
+			return nil, err
+		}
+	}
+
+	report := &RestoreReport{
+		Restored:     []RestoreItem{},
+		Skipped:      []RestoreItem{},
+		NotRecreated: []RestoreItem{},
+		Failed:       []RestoreItem{},
+	}
+	for _, m := range manifest.APIs {
+		name := m.Name + ":" + m.Version
+		s, ok := state.APIs[m.ID]
+		if !ok {
+			s = &restoreAPIState{}
+			state.APIs[m.ID] = s
+		}
+		if s.Completed {
+			report.Skipped = append(report.Skipped, RestoreItem{API: name, Item: "api", ID: s.ID})
+		} else {
+			err := c.restoreAPI(m, filepath.Join(dir, filepath.FromSlash(m.Path)), s, func() error {
+				return writeJSONFile(statePath, state)
+			})
+			if err != nil {
+				report.Failed = append(report.Failed, RestoreItem{API: name, Item: "api", ID: s.ID, Reason: err.Error()})
+				continue
+			}
+			report.Restored = append(report.Restored, RestoreItem{API: name, Item: "api", ID: s.ID})
+		}
+
+		var subscriptions []Subscription
+		if err := readJSONFile(filepath.Join(dir, filepath.FromSlash(m.Path), backupSubscriptionsFile), &subscriptions); err != nil {
+			report.Failed = append(report.Failed, RestoreItem{API: name, Item: "subscriptions", Reason: err.Error()})
+			continue
+		}
+		for _, sub := range subscriptions {
+			report.NotRecreated = append(report.NotRecreated, RestoreItem{
+				API:    name,
+				Item:   "subscription",
+				ID:     sub.ID,
+				Reason: fmt.Sprintf("subscriptions require the store API (application=%s, tier=%s)", sub.ApplicationID, sub.Tier),
+			})
+		}
+	}
+	return report, nil
+}
+
+func (c *Client) restoreAPI(m BackupManifestAPI, dir string, s *restoreAPIState, save func() error) error {
+	if s.ID == "" {
+		var api APIDetail
+		if err := readJSONFile(filepath.Join(dir, backupAPIFile), &api); err != nil {
+			return err
+		}
+		def, err := NewAPIDefinitionFromFile(filepath.Join(dir, backupDefinitionFile))
+		if err != nil {
+			return err
+		}
+		api.Definition = def
+		api.ID = ""
+		api.Status = APIStatusCreated
+		api.ThumbnailURI = ""
+
+		// the API may have been created by the previous run which failed before saving the state.
+		existing, err := c.FindAPIByContextVersion(api.Context, api.Version)
+		if err != nil {
+			return err
+		}
+		if existing != nil {
+			s.ID = existing.ID
+		} else {
+			created, err := c.CreateAPI(&api)
+			if err != nil {
+				return err
+			}
+			s.ID = created.ID
+		}
+		if err := save(); err != nil {
+			return err
+		}
+	}
+	if !s.Thumbnail {
+		f, err := os.Open(filepath.Join(dir, backupThumbnailFile))
+		if err == nil {
+			// the stored bytes are uploaded without the validation, which the backed up thumbnail may not pass
+			_, err = c.UploadThumbnail(s.ID, f)
+			f.Close()
+			if err != nil {
+				return err
+			}
+		} else if !os.IsNotExist(err) {
+			return err
+		}
+		s.Thumbnail = true
+		if err := save(); err != nil {
+			return err
+		}
+	}
+	actions, err := lifecycleActionsTo(m.Status)
+	if err != nil {
+		return err
+	}
+	for ; s.Actions < len(actions); s.Actions++ {
+		if err := c.ChangeAPIStatus(s.ID, actions[s.Actions]); err != nil {
+			return err
+		}
+		if err := save(); err != nil {
+			return err
+		}
+	}
+	s.Completed = true
+	return save()
+}
+
+// lifecycleActionsTo returns the lifecycle actions to change the status of a created API to the status.
+func lifecycleActionsTo(status APIStatus) ([]APIAction, error) {
+	switch strings.ToLower(string(status)) {
+	case strings.ToLower(string(APIStatusCreated)):
+		return []APIAction{}, nil
+	case strings.ToLower(string(APIStatusPrototyped)):
+		return []APIAction{APIActionDeployAsPrototype}, nil
+	case strings.ToLower(string(APIStatusPublished)):
+		return []APIAction{APIActionPublish}, nil
+	case strings.ToLower(string(APIStatusBlocked)):
+		return []APIAction{APIActionPublish, APIActionBlock}, nil
+	case strings.ToLower(string(APIStatusDeprecated)):
+		return []APIAction{APIActionPublish, APIActionDeprecate}, nil
+	case strings.ToLower(string(APIStatusRetired)):
+		return []APIAction{APIActionPublish, APIActionDeprecate, APIActionRetire}, nil
+	default:
+		return nil, fmt.Errorf("unsupported API status: %s", status)
+	}
+}
+
+// ArchiveBackup writes the backup directory to w as a gzipped tarball.
+func ArchiveBackup(dir string, w io.Writer) error {
+	gw := gzip.NewWriter(w)
+	tw := tar.NewWriter(gw)
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		if rel == "." || rel == backupRestoreStateFile {
+			return nil
+		}
+		header, err := tar.FileInfoHeader(info, "")
+		if err != nil {
+			return err
+		}
+		header.Name = filepath.ToSlash(rel)
+		if err := tw.WriteHeader(header); err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		_, err = io.Copy(tw, f)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return gw.Close()
+}
+
+// ExtractBackup extracts the gzipped tarball created by ArchiveBackup to the directory.
+func ExtractBackup(r io.Reader, dir string) error {
+	gr, err := gzip.NewReader(r)
+	if err != nil {
+		return err
+	}
+	defer gr.Close()
+	tr := tar.NewReader(gr)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		name := filepath.Clean(filepath.FromSlash(header.Name))
+		if filepath.IsAbs(name) || strings.HasPrefix(name, ".."+string(filepath.Separator)) || name == ".." {
+			return fmt.Errorf("invalid file name in the archive: %s", header.Name)
+		}
+		path := filepath.Join(dir, name)
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(path, 0755); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+				return err
+			}
+			f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+			if err != nil {
+				return err
+			}
+			_, err = io.Copy(f, tr)
+			f.Close()
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func writeJSONFile(path string, v interface{}) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, data, 0644)
+}
+
+func readJSONFile(path string, v interface{}) error {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
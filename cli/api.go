@@ -3,7 +3,7 @@ package cli
 import (
 	"errors"
 	"fmt"
-	"os"
+	"io"
 	"strings"
 
 	multierror "github.com/hashicorp/go-multierror"
@@ -23,10 +23,13 @@ func (c *CLI) api() cli.Command {
 			c.apiInspect(),
 			c.apiSwagger(),
 			c.apiUpdateSwagger(),
+			c.apiGraphQLSchema(),
+			c.apiUpdateGraphQLSchema(),
 			c.apiUploadThumbnail(),
 			c.apiThumbnail(),
 			c.apiCreate(true),
 			c.apiCreate(false),
+			c.apiResources(),
 		},
 	}
 }
@@ -61,11 +64,34 @@ func (c *CLI) apiList() cli.Command {
 				Name:  "query,q",
 				Value: "",
 			},
+			cli.StringFlag{
+				Name:  "label",
+				Usage: "Filter by the microgateway label",
+			},
+			cli.StringFlag{
+				Name:  "type",
+				Usage: fmt.Sprintf("Filter by the API type (%s, %s or %s)", wso2am.APITypeHTTP, wso2am.APITypeWS, wso2am.APITypeGraphQL),
+			},
 		},
 		Action: func(ctx *cli.Context) error {
 			var query = ctx.String("query")
+			var apiType wso2am.APIType
+			if ctx.IsSet("type") {
+				t, err := wso2am.ParseAPIType(ctx.String("type"))
+				if err != nil {
+					return err
+				}
+				apiType = t
+			}
+			var label = ctx.String("label")
 			return list(func(entryc chan<- interface{}, errc chan<- error, done <-chan struct{}) {
-				c.client.SearchAPIsRaw(query, entryc, errc, done)
+				if apiType != "" || label != "" {
+					c.client.SearchAPIsWithDetailRaw(query, func(api *wso2am.APIDetail) bool {
+						return (apiType == "" || api.Type == apiType) && (label == "" || api.HasLabel(label))
+					}, entryc, errc, done)
+				} else {
+					c.client.SearchAPIsRaw(query, entryc, errc, done)
+				}
 			}, func(table *TableFormatter) {
 				table.Header("ID", "Name", "Version", "Description", "Status")
 			}, func(entry interface{}, table *TableFormatter) {
@@ -175,9 +201,9 @@ func (c *CLI) apiDelete() cli.Command {
 					case err, ok := <-errc:
 						if ok {
 							errs = multierror.Append(errs, err)
-						} else {
-							break l
+							done <- struct{}{}
 						}
+						break l
 					}
 				}
 			} else {
@@ -234,15 +260,37 @@ func (c *CLI) apiUpdateSwagger() cli.Command {
 		Name:      "update-swagger",
 		Usage:     "Update the API definition",
 		ArgsUsage: "ID SWAGGERFILE",
+		Flags: append([]cli.Flag{
+			cli.BoolFlag{
+				Name:  "no-breaking",
+				Usage: "Refuse the update if the API is published and the definition has breaking changes",
+			},
+		}, templateFlags...),
 		Action: func(ctx *cli.Context) error {
 			if ctx.NArg() != 2 {
 				return errors.New("ID and SWAGGERFILE are required")
 			}
 			id := ctx.Args().Get(0)
-			def, err := wso2am.NewAPIDefinitionFromFile(ctx.Args().Get(1))
+			def, err := c.loadAPIDefinition(ctx, ctx.Args().Get(1))
 			if err != nil {
 				return err
 			}
+			if ctx.Bool("no-breaking") {
+				api, err := c.client.API(id)
+				if err != nil {
+					return err
+				}
+				if strings.EqualFold(string(api.Status), string(wso2am.APIStatusPublished)) {
+					changes, err := c.client.DiffAPIDefinition(id, def)
+					if err != nil {
+						return err
+					}
+					if wso2am.HasBreakingChanges(changes) {
+						printAPIDefinitionChanges(changes)
+						return errors.New("the API is published and the definition has breaking changes")
+					}
+				}
+			}
 			if _, err := c.client.UpdateAPIDefinition(id, def); err != nil {
 				return err
 			}
@@ -251,16 +299,57 @@ func (c *CLI) apiUpdateSwagger() cli.Command {
 	}
 }
 
+func (c *CLI) apiGraphQLSchema() cli.Command {
+	return cli.Command{
+		Name:      "graphql-schema",
+		Usage:     "Print the GraphQL schema of the GraphQL API",
+		ArgsUsage: "ID",
+		Action: func(ctx *cli.Context) error {
+			if ctx.NArg() != 1 {
+				return errors.New("ID is required")
+			}
+			sdl, err := c.client.GraphQLSchema(ctx.Args().First())
+			if err != nil {
+				return err
+			}
+			fmt.Println(sdl)
+			return nil
+		},
+	}
+}
+
+func (c *CLI) apiUpdateGraphQLSchema() cli.Command {
+	return cli.Command{
+		Name:      "update-graphql-schema",
+		Usage:     "Update the GraphQL schema and the operations of the GraphQL API",
+		ArgsUsage: "ID SCHEMAFILE",
+		Action: func(ctx *cli.Context) error {
+			if ctx.NArg() != 2 {
+				return errors.New("ID and SCHEMAFILE are required")
+			}
+			schema, err := wso2am.LoadGraphQLSchema(ctx.Args().Get(1))
+			if err != nil {
+				return err
+			}
+			return c.client.UpdateGraphQLSchema(ctx.Args().Get(0), schema)
+		},
+	}
+}
+
 func (c *CLI) apiThumbnail() cli.Command {
 	return cli.Command{
-		Name:  "thumbnail",
-		Usage: "Download the thumbnail",
+		Name:      "thumbnail",
+		Usage:     "Download the thumbnail",
+		ArgsUsage: "ID",
+		Flags:     thumbnailDownloadFlags,
 		Action: func(ctx *cli.Context) error {
 			if ctx.NArg() != 1 {
 				return errors.New("ID is required")
 			}
 			id := ctx.Args().Get(0)
-			return c.client.Thumbnail(id, os.Stdout)
+			return c.downloadThumbnail(ctx, func(w io.Writer) (string, error) {
+				return c.client.ThumbnailWithMediaType(id, w)
+			})
 		},
 	}
 }
@@ -270,18 +359,17 @@ func (c *CLI) apiUploadThumbnail() cli.Command {
 		Name:      "upload-thumbnail",
 		Usage:     "Upload the thumbnail",
 		ArgsUsage: "ID FILE",
+		Flags:     thumbnailUploadFlags,
 		Action: func(ctx *cli.Context) error {
 			if ctx.NArg() != 2 {
 				return errors.New("ID and FILE are required")
 			}
 			id := ctx.Args().Get(0)
-			file := ctx.Args().Get(1)
-			f, err := os.Open(file)
+			img, err := c.readThumbnail(ctx, ctx.Args().Get(1))
 			if err != nil {
 				return err
 			}
-			defer f.Close()
-			if _, err := c.client.UploadThumbnail(id, f); err != nil {
+			if _, err := c.client.UploadThumbnailImage(id, img); err != nil {
 				return err
 			}
 			return nil
@@ -296,7 +384,8 @@ func (c *CLI) apiCreate(update bool) cli.Command {
 	var commandArgsUsage string
 	flags := []cli.Flag{
 		cli.StringFlag{
-			Name: "definition",
+			Name:  "definition",
+			Usage: "API definition file (not required for the WebSocket APIs)",
 		},
 		cli.StringFlag{
 			Name: "name",
@@ -319,7 +408,16 @@ func (c *CLI) apiCreate(update bool) cli.Command {
 			Value: "http://localhost/",
 		},
 		cli.StringFlag{
-			Name: "gateway-env",
+			Name:  "gateway-env",
+			Usage: "Comma separated gateway environments (see 'env list')",
+		},
+		cli.StringSliceFlag{
+			Name:  "tier",
+			Usage: "Subscription tier of the API (see 'tier list')",
+		},
+		cli.StringSliceFlag{
+			Name:  "label",
+			Usage: "Microgateway label to deploy the API to (see 'label list')",
 		},
 		cli.BoolFlag{
 			Name: "publish,P",
@@ -328,6 +426,7 @@ func (c *CLI) apiCreate(update bool) cli.Command {
 			Name: "visible-role",
 		},
 	}
+	flags = append(flags, templateFlags...)
 	if update {
 		commandName = "update"
 		commandUsage = "Update the API"
@@ -338,6 +437,20 @@ func (c *CLI) apiCreate(update bool) cli.Command {
 		commandUsage = "Create the API"
 		flags = append(flags, cli.BoolFlag{
 			Name: "update",
+		}, cli.StringFlag{
+			Name:  "type",
+			Usage: fmt.Sprintf("API type (%s, %s or %s)", wso2am.APITypeHTTP, wso2am.APITypeWS, wso2am.APITypeGraphQL),
+			Value: string(wso2am.APITypeHTTP),
+		}, cli.StringFlag{
+			Name:  "graphql",
+			Usage: "GraphQL schema (SDL) file of the GraphQL API (--definition is not required)",
+		}, cli.StringFlag{
+			Name:  "wsdl",
+			Usage: "WSDL URL (or file with --apiversion v1 or later) of the SOAP backend (--definition and --production-url are not required)",
+		}, cli.StringFlag{
+			Name:  "wsdl-type",
+			Usage: fmt.Sprintf("How to expose the SOAP backend (%s or %s)", wso2am.WSDLImplementationSOAP, wso2am.WSDLImplementationSOAPToREST),
+			Value: string(wso2am.WSDLImplementationSOAP),
 		})
 	}
 	return cli.Command{
@@ -357,8 +470,55 @@ func (c *CLI) apiCreate(update bool) cli.Command {
 						return fmt.Errorf(`"Cannot update %v"`, unmodifiableFlags)
 					}
 				}
-			} else {
-				if err := c.checkRequiredParameters(ctx, "definition", "name", "context", "version", "production-url", "gateway-env"); err != nil {
+			}
+			var apiType = wso2am.APITypeHTTP
+			var wsdl *wso2am.WSDL
+			var wsdlType wso2am.WSDLImplementationType
+			var graphQLSchema *wso2am.GraphQLSchema
+			if !update {
+				t, err := wso2am.ParseAPIType(ctx.String("type"))
+				if err != nil {
+					return err
+				}
+				apiType = t
+				if ctx.IsSet("graphql") {
+					apiType = wso2am.APITypeGraphQL
+				}
+				required := []string{"name", "context", "version", "gateway-env"}
+				if apiType == wso2am.APITypeGraphQL {
+					if ctx.IsSet("wsdl") {
+						return errors.New("--wsdl cannot be used for the GraphQL APIs")
+					}
+					if err := c.checkRequiredParameters(ctx, "graphql"); err != nil {
+						return err
+					}
+					// check before any write not to leave the API half-updated with --update
+					if err := c.client.CheckGraphQLSupported(); err != nil {
+						return err
+					}
+					// validate the schema locally before calling the API
+					if graphQLSchema, err = wso2am.LoadGraphQLSchema(ctx.String("graphql")); err != nil {
+						return err
+					}
+					required = append(required, "production-url")
+				} else if ctx.IsSet("wsdl") {
+					if apiType == wso2am.APITypeWS {
+						return errors.New("--wsdl cannot be used for the WebSocket APIs")
+					}
+					if wsdlType, err = wso2am.ParseWSDLImplementationType(ctx.String("wsdl-type")); err != nil {
+						return err
+					}
+					// validate the WSDL locally before calling the API
+					if wsdl, err = wso2am.LoadWSDL(ctx.String("wsdl")); err != nil {
+						return err
+					}
+				} else {
+					required = append(required, "production-url")
+					if apiType != wso2am.APITypeWS {
+						required = append(required, "definition")
+					}
+				}
+				if err := c.checkRequiredParameters(ctx, required...); err != nil {
 					return err
 				}
 			}
@@ -371,13 +531,23 @@ func (c *CLI) apiCreate(update bool) cli.Command {
 					return err
 				}
 				api = a
+			} else if apiType == wso2am.APITypeWS {
+				api = c.client.NewWebSocketAPI()
+			} else if graphQLSchema != nil {
+				api = c.client.NewGraphQLAPI(graphQLSchema)
+			} else if wsdl != nil {
+				a, err := c.client.NewWSDLAPI(wsdl, wsdlType)
+				if err != nil {
+					return err
+				}
+				api = a
 			} else {
 				api = c.client.NewAPI()
 			}
 
 			if ctx.IsSet("definition") {
 				swaggerFile := ctx.String("definition")
-				def, err := wso2am.NewAPIDefinitionFromFile(swaggerFile)
+				def, err := c.loadAPIDefinition(ctx, swaggerFile)
 				if err != nil {
 					return err
 				}
@@ -393,8 +563,24 @@ func (c *CLI) apiCreate(update bool) cli.Command {
 				api.Version = ctx.String("version")
 			}
 			if ctx.IsSet("gateway-env") {
+				if err := c.client.ValidateGatewayEnvironments(ctx.String("gateway-env")); err != nil {
+					return err
+				}
 				api.GatewayEnvironments = ctx.String("gateway-env")
 			}
+			if ctx.IsSet("tier") {
+				if err := c.client.ValidateTiers(wso2am.TierLevelAPI, ctx.StringSlice("tier")); err != nil {
+					return err
+				}
+				api.Tiers = ctx.StringSlice("tier")
+			}
+			if ctx.IsSet("label") {
+				labels, err := c.client.LabelsByName(ctx.StringSlice("label"))
+				if err != nil {
+					return err
+				}
+				api.Labels = labels
+			}
 			if ctx.IsSet("provider") {
 				api.Provider = ctx.String("provider")
 			}
@@ -405,27 +591,52 @@ func (c *CLI) apiCreate(update bool) cli.Command {
 
 			// endpoint config
 			if ctx.IsSet("production-url") || ctx.IsSet("sandbox-url") {
-				endpointConfig := &wso2am.APIEndpointConfig{
-					Type: "http",
+				productionURL, err := c.render(ctx, "production-url")
+				if err != nil {
+					return err
 				}
-				var productionURL = ctx.String("production-url")
-				var sandboxURL = ctx.String("sandbox-url")
-				endpointConfig.ProductionEndpoints = &wso2am.APIEndpoint{
-					URL: productionURL,
+				sandboxURL, err := c.render(ctx, "sandbox-url")
+				if err != nil {
+					return err
 				}
-				if sandboxURL != "" {
-					endpointConfig.SandboxEndpoints = &wso2am.APIEndpoint{
-						URL: sandboxURL,
+				if wsdl != nil {
+					if !ctx.IsSet("production-url") {
+						productionURL = wsdl.EndpointURL()
+					}
+					if !ctx.IsSet("sandbox-url") {
+						sandboxURL = ""
+					}
+					api.SetEndpointConfig(wso2am.NewWSDLEndpointConfig(productionURL, sandboxURL))
+				} else if api.Type == wso2am.APITypeWS {
+					if !ctx.IsSet("sandbox-url") {
+						sandboxURL = ""
+					}
+					endpointConfig, err := wso2am.NewWebSocketEndpointConfig(productionURL, sandboxURL)
+					if err != nil {
+						return err
+					}
+					api.SetEndpointConfig(endpointConfig)
+				} else {
+					endpointConfig := &wso2am.APIEndpointConfig{
+						Type: "http",
 					}
+					endpointConfig.ProductionEndpoints = &wso2am.APIEndpoint{
+						URL: productionURL,
+					}
+					if sandboxURL != "" {
+						endpointConfig.SandboxEndpoints = &wso2am.APIEndpoint{
+							URL: sandboxURL,
+						}
+					}
+					api.SetEndpointConfig(endpointConfig)
 				}
-				api.SetEndpointConfig(endpointConfig)
 			}
 
 			// if "--update" is specified with create command, find the API ID and update it.
 			updateOrCreate := ctx.Bool("update")
 			if updateOrCreate {
 				// find API ID by context and version
-				a, err := c.findAPIByContextVersion(api.Context, api.Version)
+				a, err := c.client.FindAPIByContextVersion(api.Context, api.Version)
 				if err != nil {
 					return err
 				}
@@ -439,6 +650,14 @@ func (c *CLI) apiCreate(update bool) cli.Command {
 			var err error
 			if update || (updateOrCreate && api.ID != "") {
 				res, err = c.client.UpdateAPI(api)
+				if err == nil && graphQLSchema != nil {
+					// the schema is not a part of the API, and it updates the operations of the API
+					err = c.client.UpdateGraphQLSchema(res.ID, graphQLSchema)
+				}
+			} else if graphQLSchema != nil {
+				res, err = c.client.CreateGraphQLAPI(api, graphQLSchema)
+			} else if wsdl != nil {
+				res, err = c.client.CreateWSDLAPI(api, wsdl, wsdlType)
 			} else {
 				res, err = c.client.CreateAPI(api)
 			}
@@ -459,28 +678,3 @@ func (c *CLI) apiCreate(update bool) cli.Command {
 		},
 	}
 }
-
-func (c *CLI) findAPIByContextVersion(context, version string) (*wso2am.API, error) {
-	result, err := c.client.SearchResultToSlice(func(entryc chan<- interface{}, errc chan<- error, done <-chan struct{}) {
-		c.client.SearchAPIsRaw(fmt.Sprintf("context:%s", context), entryc, errc, done)
-	})
-	if err != nil {
-		return nil, err
-	}
-	normalizeContext := func(context string) string {
-		if strings.HasPrefix(context, "/") {
-			context = context[1:]
-		}
-		if strings.HasSuffix(context, "/") {
-			context = context[1 : len(context)-1]
-		}
-		return context
-	}
-	for _, v := range result {
-		api := c.client.ConvertToAPI(v)
-		if normalizeContext(api.Context) == normalizeContext(context) && api.Version == version {
-			return api, nil
-		}
-	}
-	return nil, nil
-}
@@ -0,0 +1,239 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	multierror "github.com/hashicorp/go-multierror"
+	wso2am "github.com/uphy/go-wso2am"
+	"github.com/urfave/cli"
+)
+
+func (c *CLI) application() cli.Command {
+	return cli.Command{
+		Name:  "app",
+		Usage: "Application management command",
+		Subcommands: cli.Commands{
+			c.applicationList(),
+			c.applicationInspect(),
+			c.applicationCreate(false),
+			c.applicationCreate(true),
+			c.applicationDelete(),
+			c.applicationKeys(),
+			c.applicationToken(),
+		},
+	}
+}
+
+func (c *CLI) applicationList() cli.Command {
+	return cli.Command{
+		Name:    "list",
+		Aliases: []string{"ls", "dir"},
+		Usage:   "List the applications",
+		Flags: []cli.Flag{
+			cli.StringFlag{
+				Name:  "query,q",
+				Usage: "Part of the application name",
+			},
+			formatFlag,
+		},
+		Action: func(ctx *cli.Context) error {
+			format, err := outputFormat(ctx)
+			if err != nil {
+				return err
+			}
+			query := ctx.String("query")
+			searchFunc := func(entryc chan<- interface{}, errc chan<- error, done <-chan struct{}) {
+				c.client.SearchApplicationsRaw(query, entryc, errc, done)
+			}
+			if format == formatJSON {
+				result, err := c.client.SearchResultToSlice(searchFunc)
+				if err != nil {
+					return err
+				}
+				apps := []*wso2am.Application{}
+				for _, v := range result {
+					apps = append(apps, c.client.ConvertToApplication(v))
+				}
+				return c.inspect(apps)
+			}
+			return list(searchFunc, func(table *TableFormatter) {
+				table.Header("ID", "Name", "Tier", "Status", "Subscriber", "Groups")
+			}, func(entry interface{}, table *TableFormatter) {
+				a := c.client.ConvertToApplication(entry)
+				table.Row(a.ID, a.Name, a.ThrottlingTier, a.Status, a.Subscriber, a.GroupID)
+			})
+		},
+	}
+}
+
+func (c *CLI) applicationInspect() cli.Command {
+	return cli.Command{
+		Name:      "inspect",
+		Aliases:   []string{"show", "cat"},
+		Usage:     "Inspect the application",
+		ArgsUsage: "ID|NAME",
+		Action: func(ctx *cli.Context) error {
+			if ctx.NArg() != 1 {
+				return errors.New("ID or NAME is required")
+			}
+			app, err := c.findApplication(ctx.Args().First())
+			if err != nil {
+				return err
+			}
+			return c.inspect(app)
+		},
+	}
+}
+
+func (c *CLI) applicationCreate(update bool) cli.Command {
+	var commandName string
+	var commandAliases []string
+	var commandUsage string
+	var commandArgsUsage string
+	flags := []cli.Flag{
+		cli.StringFlag{
+			Name:  "tier",
+			Usage: "Application throttling tier (see 'tier list --level application')",
+		},
+		cli.StringFlag{
+			Name: "callback-url",
+		},
+		cli.StringFlag{
+			Name: "description",
+		},
+		cli.StringSliceFlag{
+			Name:  "group",
+			Usage: "Group which shares the application",
+		},
+		cli.StringSliceFlag{
+			Name:  "attribute",
+			Usage: "Application attribute (KEY=VALUE)",
+		},
+	}
+	if update {
+		commandName = "update"
+		commandUsage = "Update the application"
+		commandArgsUsage = "ID|NAME"
+		flags = append(flags, cli.StringFlag{
+			Name:  "name",
+			Usage: "New name of the application",
+		})
+	} else {
+		commandName = "create"
+		commandAliases = []string{"new"}
+		commandUsage = "Create the application"
+		commandArgsUsage = "NAME"
+	}
+	return cli.Command{
+		Name:      commandName,
+		Aliases:   commandAliases,
+		Usage:     commandUsage,
+		ArgsUsage: commandArgsUsage,
+		Flags:     flags,
+		Action: func(ctx *cli.Context) error {
+			var app *wso2am.Application
+			if update {
+				if ctx.NArg() != 1 {
+					return errors.New("ID or NAME is required")
+				}
+				a, err := c.findApplication(ctx.Args().First())
+				if err != nil {
+					return err
+				}
+				app = a
+				if ctx.IsSet("name") {
+					app.Name = ctx.String("name")
+				}
+			} else {
+				if ctx.NArg() != 1 {
+					return errors.New("NAME is required")
+				}
+				app = c.client.NewApplication(ctx.Args().First())
+			}
+
+			if ctx.IsSet("tier") {
+				if err := c.client.ValidateTiers(wso2am.TierLevelApplication, []string{ctx.String("tier")}); err != nil {
+					return err
+				}
+				app.ThrottlingTier = ctx.String("tier")
+			}
+			if ctx.IsSet("callback-url") {
+				app.CallbackURL = ctx.String("callback-url")
+			}
+			if ctx.IsSet("description") {
+				app.Description = ctx.String("description")
+			}
+			if ctx.IsSet("group") {
+				app.GroupID = strings.Join(ctx.StringSlice("group"), ",")
+			}
+			if ctx.IsSet("attribute") {
+				if app.Attributes == nil {
+					app.Attributes = map[string]string{}
+				}
+				for _, attr := range ctx.StringSlice("attribute") {
+					kv := strings.SplitN(attr, "=", 2)
+					if len(kv) != 2 || kv[0] == "" {
+						return fmt.Errorf("invalid attribute %q (expected KEY=VALUE)", attr)
+					}
+					app.Attributes[kv[0]] = kv[1]
+				}
+			}
+
+			if update {
+				_, err := c.client.UpdateApplication(app)
+				return err
+			}
+			res, err := c.client.CreateApplication(app)
+			if err != nil {
+				return err
+			}
+			fmt.Println(res.ID)
+			return nil
+		},
+	}
+}
+
+func (c *CLI) applicationDelete() cli.Command {
+	return cli.Command{
+		Name:      "delete",
+		Aliases:   []string{"del", "rm"},
+		Usage:     "Delete the applications",
+		ArgsUsage: "ID|NAME...",
+		Action: func(ctx *cli.Context) error {
+			if ctx.NArg() == 0 {
+				return errors.New("ID or NAME is required")
+			}
+			var errs error
+			for _, idOrName := range ctx.Args() {
+				app, err := c.findApplication(idOrName)
+				if err == nil {
+					err = c.client.DeleteApplication(app.ID)
+				}
+				if err != nil {
+					errs = multierror.Append(errs, err)
+					fmt.Println(err)
+				} else {
+					fmt.Println(app.ID)
+				}
+			}
+			return errs
+		},
+	}
+}
+
+// findApplication finds the application by the ID, or by the name if it is not a UUID.
+func (c *CLI) findApplication(idOrName string) (*wso2am.Application, error) {
+	if wso2am.UUIDPattern.MatchString(idOrName) {
+		return c.client.Application(idOrName)
+	}
+	app, err := c.client.ApplicationByName(idOrName)
+	if err != nil {
+		return nil, err
+	}
+	if app == nil {
+		return nil, fmt.Errorf("application not found: %s", idOrName)
+	}
+	return app, nil
+}
@@ -0,0 +1,305 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	wso2am "github.com/uphy/go-wso2am"
+	"github.com/urfave/cli"
+)
+
+// maskedSecret replaces the secrets which are not requested to show.
+const maskedSecret = "********"
+
+var (
+	keyTypeFlag = cli.StringFlag{
+		Name:  "key-type,t",
+		Usage: "Key type (production or sandbox)",
+		Value: "production",
+	}
+	showSecretFlag = cli.BoolFlag{
+		Name:  "show-secret",
+		Usage: "Show the consumer secret and the access token",
+	}
+)
+
+func (c *CLI) applicationKeys() cli.Command {
+	return cli.Command{
+		Name:  "keys",
+		Usage: "Application key management command",
+		Subcommands: cli.Commands{
+			c.applicationKeysGenerate(),
+			c.applicationKeysShow(),
+			c.applicationKeysRegenerate(),
+			c.applicationKeysUpdate(),
+			c.applicationKeysMap(),
+		},
+	}
+}
+
+func (c *CLI) applicationKeysGenerate() cli.Command {
+	return cli.Command{
+		Name:      "generate",
+		Aliases:   []string{"gen"},
+		Usage:     "Generate the consumer key and secret of the application",
+		ArgsUsage: "ID|NAME",
+		Flags: []cli.Flag{
+			keyTypeFlag,
+			cli.StringSliceFlag{
+				Name:  "grant-type",
+				Usage: "Supported grant type (default: client_credentials, password and refresh_token)",
+			},
+			cli.StringFlag{
+				Name: "callback-url",
+			},
+			cli.StringSliceFlag{
+				Name:  "scope",
+				Usage: "Scope of the access token generated with the keys",
+			},
+			cli.IntFlag{
+				Name:  "validity",
+				Usage: "Validity of the access token in seconds",
+				Value: 3600,
+			},
+			showSecretFlag,
+			formatFlag,
+		},
+		Action: func(ctx *cli.Context) error {
+			app, keyType, err := c.applicationAndKeyType(ctx)
+			if err != nil {
+				return err
+			}
+			req := wso2am.NewApplicationKeyGenerateRequest(keyType)
+			if ctx.IsSet("grant-type") {
+				req.SupportedGrantTypes = ctx.StringSlice("grant-type")
+			}
+			if ctx.IsSet("scope") {
+				req.Scopes = ctx.StringSlice("scope")
+			}
+			req.CallbackURL = ctx.String("callback-url")
+			req.ValidityTime = fmt.Sprint(ctx.Int("validity"))
+			key, err := c.client.GenerateApplicationKeys(app.ID, req)
+			if err != nil {
+				return err
+			}
+			return c.printApplicationKey(ctx, key)
+		},
+	}
+}
+
+func (c *CLI) applicationKeysShow() cli.Command {
+	return cli.Command{
+		Name:      "show",
+		Aliases:   []string{"inspect"},
+		Usage:     "Show the keys of the application",
+		ArgsUsage: "ID|NAME",
+		Flags: []cli.Flag{
+			keyTypeFlag,
+			showSecretFlag,
+			formatFlag,
+		},
+		Action: func(ctx *cli.Context) error {
+			app, keyType, err := c.applicationAndKeyType(ctx)
+			if err != nil {
+				return err
+			}
+			key, err := c.client.ApplicationKeys(app.ID, keyType)
+			if err != nil {
+				return err
+			}
+			return c.printApplicationKey(ctx, key)
+		},
+	}
+}
+
+func (c *CLI) applicationKeysRegenerate() cli.Command {
+	return cli.Command{
+		Name:        "regenerate",
+		Usage:       "Regenerate the consumer secret of the application",
+		Description: "Regenerate the consumer secret.  The old secret stops working immediately, so the new one is always printed once to stderr unless --show-secret prints it with the keys.",
+		ArgsUsage:   "ID|NAME",
+		Flags: []cli.Flag{
+			keyTypeFlag,
+			showSecretFlag,
+			formatFlag,
+		},
+		Action: func(ctx *cli.Context) error {
+			app, keyType, err := c.applicationAndKeyType(ctx)
+			if err != nil {
+				return err
+			}
+			key, err := c.client.RegenerateConsumerSecret(app.ID, keyType)
+			if err != nil {
+				return err
+			}
+			if !ctx.Bool("show-secret") {
+				// the new secret cannot be shown again, and the application stops working without it
+				fmt.Fprintf(os.Stderr, "new consumer secret: %s\n", key.ConsumerSecret)
+			}
+			return c.printApplicationKey(ctx, key)
+		},
+	}
+}
+
+func (c *CLI) applicationKeysUpdate() cli.Command {
+	return cli.Command{
+		Name:      "update",
+		Usage:     "Update the grant types and the callback URL of the keys",
+		ArgsUsage: "ID|NAME",
+		Flags: []cli.Flag{
+			keyTypeFlag,
+			cli.StringSliceFlag{
+				Name:  "grant-type",
+				Usage: "Supported grant type (replaces the current grant types)",
+			},
+			cli.StringFlag{
+				Name: "callback-url",
+			},
+		},
+		Action: func(ctx *cli.Context) error {
+			app, keyType, err := c.applicationAndKeyType(ctx)
+			if err != nil {
+				return err
+			}
+			if !ctx.IsSet("grant-type") && !ctx.IsSet("callback-url") {
+				return errors.New("--grant-type or --callback-url is required")
+			}
+			key, err := c.client.ApplicationKeys(app.ID, keyType)
+			if err != nil {
+				return err
+			}
+			grantTypes := key.SupportedGrantTypes
+			if ctx.IsSet("grant-type") {
+				grantTypes = ctx.StringSlice("grant-type")
+			}
+			callbackURL := key.CallbackURL
+			if ctx.IsSet("callback-url") {
+				callbackURL = ctx.String("callback-url")
+			}
+			_, err = c.client.UpdateApplicationKeys(app.ID, keyType, grantTypes, callbackURL)
+			return err
+		},
+	}
+}
+
+func (c *CLI) applicationKeysMap() cli.Command {
+	return cli.Command{
+		Name:      "map",
+		Usage:     "Map the existing OAuth client to the application",
+		ArgsUsage: "ID|NAME",
+		Flags: []cli.Flag{
+			keyTypeFlag,
+			cli.StringFlag{
+				Name: "consumer-key",
+			},
+			cli.StringFlag{
+				Name:   "consumer-secret",
+				EnvVar: "WSO2_CONSUMER_SECRET",
+			},
+		},
+		Action: func(ctx *cli.Context) error {
+			app, keyType, err := c.applicationAndKeyType(ctx)
+			if err != nil {
+				return err
+			}
+			if err := c.checkRequiredParameters(ctx, "consumer-key"); err != nil {
+				return err
+			}
+			if ctx.String("consumer-secret") == "" {
+				return errors.New("--consumer-secret or WSO2_CONSUMER_SECRET is required")
+			}
+			_, err = c.client.MapApplicationKeys(app.ID, &wso2am.ApplicationKeyMappingRequest{
+				ConsumerKey:    ctx.String("consumer-key"),
+				ConsumerSecret: ctx.String("consumer-secret"),
+				KeyType:        keyType,
+			})
+			return err
+		},
+	}
+}
+
+func (c *CLI) applicationToken() cli.Command {
+	return cli.Command{
+		Name:      "token",
+		Usage:     "Obtain the access token with the keys of the application",
+		ArgsUsage: "ID|NAME",
+		Flags: []cli.Flag{
+			keyTypeFlag,
+			cli.StringSliceFlag{
+				Name:  "scope",
+				Usage: "Scope of the access token",
+			},
+			cli.IntFlag{
+				Name:  "validity",
+				Usage: "Validity of the access token in seconds (default: server default)",
+			},
+			cli.BoolFlag{
+				Name:  "verbose,v",
+				Usage: "Show the scope and the expiry along with the token as JSON",
+			},
+		},
+		Action: func(ctx *cli.Context) error {
+			app, keyType, err := c.applicationAndKeyType(ctx)
+			if err != nil {
+				return err
+			}
+			key, err := c.client.ApplicationKeys(app.ID, keyType)
+			if err != nil {
+				return err
+			}
+			token, err := c.client.ApplicationAccessToken(key, ctx.StringSlice("scope"), ctx.Int("validity"))
+			if err != nil {
+				return err
+			}
+			if ctx.Bool("verbose") {
+				return c.inspect(token)
+			}
+			fmt.Println(token.AccessToken)
+			return nil
+		},
+	}
+}
+
+func (c *CLI) applicationAndKeyType(ctx *cli.Context) (*wso2am.Application, wso2am.ApplicationKeyType, error) {
+	if ctx.NArg() != 1 {
+		return nil, "", errors.New("ID or NAME is required")
+	}
+	keyType, err := wso2am.ParseApplicationKeyType(ctx.String("key-type"))
+	if err != nil {
+		return nil, "", err
+	}
+	app, err := c.findApplication(ctx.Args().First())
+	if err != nil {
+		return nil, "", err
+	}
+	return app, keyType, nil
+}
+
+// printApplicationKey prints the key masking the secrets unless --show-secret is set.
+func (c *CLI) printApplicationKey(ctx *cli.Context, key *wso2am.ApplicationKey) error {
+	format, err := outputFormat(ctx)
+	if err != nil {
+		return err
+	}
+	k := *key
+	if !ctx.Bool("show-secret") {
+		if k.ConsumerSecret != "" {
+			k.ConsumerSecret = maskedSecret
+		}
+		if k.Token != nil && k.Token.AccessToken != "" {
+			token := *k.Token
+			token.AccessToken = maskedSecret
+			k.Token = &token
+		}
+	}
+	if format == formatJSON {
+		return c.inspect(k)
+	}
+	f := newTableFormatter()
+	f.Header("KeyType", "ConsumerKey", "ConsumerSecret", "GrantTypes", "State")
+	f.Row(k.KeyType, k.ConsumerKey, k.ConsumerSecret, strings.Join(k.SupportedGrantTypes, ","), k.KeyState)
+	f.Flush()
+	return nil
+}
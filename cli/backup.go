@@ -0,0 +1,130 @@
+package cli
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+	"time"
+
+	wso2am "github.com/uphy/go-wso2am"
+	"github.com/urfave/cli"
+)
+
+func (c *CLI) backup() cli.Command {
+	return cli.Command{
+		Name:  "backup",
+		Usage: "Backup all APIs",
+		Description: `Backup all APIs with the definitions, thumbnails and subscriptions.
+
+If the output ends with ".tar.gz" or ".tgz", the backup is written as a tarball.
+Otherwise it is written to the directory.`,
+		Flags: []cli.Flag{
+			cli.StringFlag{
+				Name:  "output,o",
+				Usage: "Output directory or tarball (default: wso2am-backup-TIMESTAMP)",
+			},
+		},
+		Action: func(ctx *cli.Context) error {
+			output := ctx.String("output")
+			if output == "" {
+				output = "wso2am-backup-" + time.Now().Format("20060102-150405")
+			}
+			if !isArchive(output) {
+				m, err := c.client.Backup(output)
+				if err != nil {
+					return err
+				}
+				fmt.Printf("%d APIs were written to %s\n", len(m.APIs), output)
+				return nil
+			}
+
+			dir, err := ioutil.TempDir("", "wso2am-backup")
+			if err != nil {
+				return err
+			}
+			defer os.RemoveAll(dir)
+			m, err := c.client.Backup(dir)
+			if err != nil {
+				return err
+			}
+			f, err := os.Create(output)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			if err := wso2am.ArchiveBackup(dir, f); err != nil {
+				return err
+			}
+			fmt.Printf("%d APIs were written to %s\n", len(m.APIs), output)
+			return nil
+		},
+	}
+}
+
+func (c *CLI) restore() cli.Command {
+	return cli.Command{
+		Name:  "restore",
+		Usage: "Restore APIs from the backup",
+		Description: `Restore APIs from the backup directory or tarball.
+
+The progress is recorded in the backup directory.  If the restore failed, run
+the same command again to resume it.  A tarball is extracted to the work directory
+(default: the tarball name without the extension), which is reused on resume.`,
+		ArgsUsage: "BACKUP",
+		Flags: []cli.Flag{
+			cli.StringFlag{
+				Name:  "work-dir",
+				Usage: "Directory to extract the tarball",
+			},
+		},
+		Action: func(ctx *cli.Context) error {
+			if ctx.NArg() != 1 {
+				return fmt.Errorf("BACKUP is required")
+			}
+			dir := ctx.Args().First()
+			if isArchive(dir) {
+				workDir := ctx.String("work-dir")
+				if workDir == "" {
+					workDir = strings.TrimSuffix(strings.TrimSuffix(dir, ".tgz"), ".tar.gz")
+				}
+				if _, err := os.Stat(workDir); os.IsNotExist(err) {
+					f, err := os.Open(dir)
+					if err != nil {
+						return err
+					}
+					defer f.Close()
+					if err := wso2am.ExtractBackup(f, workDir); err != nil {
+						return err
+					}
+				}
+				dir = workDir
+			}
+
+			report, err := c.client.Restore(dir)
+			if err != nil {
+				return err
+			}
+			f := newTableFormatter()
+			f.Header("Result", "API", "Item", "ID", "Reason")
+			rows := func(result string, items []wso2am.RestoreItem) {
+				for _, item := range items {
+					f.Row(result, item.API, item.Item, item.ID, item.Reason)
+				}
+			}
+			rows("Restored", report.Restored)
+			rows("Skipped", report.Skipped)
+			rows("NotRecreated", report.NotRecreated)
+			rows("Failed", report.Failed)
+			f.Flush()
+			if len(report.Failed) > 0 {
+				return fmt.Errorf("%d items failed to restore.  run the restore again to resume", len(report.Failed))
+			}
+			return nil
+		},
+	}
+}
+
+func isArchive(path string) bool {
+	return strings.HasSuffix(path, ".tar.gz") || strings.HasSuffix(path, ".tgz")
+}
@@ -0,0 +1,302 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	wso2am "github.com/uphy/go-wso2am"
+	"github.com/urfave/cli"
+)
+
+// clientCertificateFlag switches the cert subcommands from the endpoint certificates to the client certificates.
+var clientCertificateFlag = cli.BoolFlag{
+	Name:  "client",
+	Usage: "Manage the client certificates of the APIs instead of the endpoint certificates",
+}
+
+const certificateTimeFormat = "2006-01-02"
+
+func (c *CLI) certificate() cli.Command {
+	return cli.Command{
+		Name:    "cert",
+		Aliases: []string{"certificate"},
+		Usage:   "Endpoint and client certificate management command",
+		Subcommands: cli.Commands{
+			c.certificateList(),
+			c.certificateAdd(),
+			c.certificateInspect(),
+			c.certificateUpdate(),
+			c.certificateDelete(),
+			c.certificateExpiring(),
+		},
+	}
+}
+
+func (c *CLI) certificateList() cli.Command {
+	return cli.Command{
+		Name:    "list",
+		Aliases: []string{"ls", "dir"},
+		Usage:   "List the certificates",
+		Flags: []cli.Flag{
+			clientCertificateFlag,
+			cli.StringFlag{
+				Name:  "alias",
+				Usage: "Filter by the alias",
+			},
+			cli.StringFlag{
+				Name:  "endpoint",
+				Usage: "Filter by the endpoint URL",
+			},
+			cli.StringFlag{
+				Name:  "api",
+				Usage: "Filter by the API ID (client certificates only)",
+			},
+			formatFlag,
+		},
+		Action: func(ctx *cli.Context) error {
+			format, err := outputFormat(ctx)
+			if err != nil {
+				return err
+			}
+			filter := wso2am.CertificateFilter{
+				Alias:    ctx.String("alias"),
+				Endpoint: ctx.String("endpoint"),
+				APIID:    ctx.String("api"),
+			}
+			f := newTableFormatter()
+			if ctx.Bool("client") {
+				certs, err := c.client.ClientCertificates(filter)
+				if err != nil {
+					return err
+				}
+				if format == formatJSON {
+					return c.inspect(certs)
+				}
+				f.Header("Alias", "API", "Tier")
+				for _, cert := range certs {
+					f.Row(cert.Alias, cert.APIID, cert.Tier)
+				}
+			} else {
+				certs, err := c.client.EndpointCertificates(filter)
+				if err != nil {
+					return err
+				}
+				if format == formatJSON {
+					return c.inspect(certs)
+				}
+				f.Header("Alias", "Endpoint")
+				for _, cert := range certs {
+					f.Row(cert.Alias, cert.Endpoint)
+				}
+			}
+			f.Flush()
+			return nil
+		},
+	}
+}
+
+func (c *CLI) certificateAdd() cli.Command {
+	return cli.Command{
+		Name:      "add",
+		Usage:     "Upload the certificate",
+		ArgsUsage: "ALIAS FILE",
+		Flags: []cli.Flag{
+			clientCertificateFlag,
+			cli.StringFlag{
+				Name:  "endpoint",
+				Usage: "Endpoint URL (endpoint certificates only)",
+			},
+			cli.StringFlag{
+				Name:  "api",
+				Usage: "API ID (client certificates only)",
+			},
+			cli.StringFlag{
+				Name:  "tier",
+				Usage: "Throttling tier of the client (client certificates only)",
+			},
+		},
+		Action: func(ctx *cli.Context) error {
+			if ctx.NArg() != 2 {
+				return errors.New("ALIAS and FILE are required")
+			}
+			alias := ctx.Args().Get(0)
+			data, _, err := wso2am.ReadCertificateFile(ctx.Args().Get(1))
+			if err != nil {
+				return err
+			}
+			if ctx.Bool("client") {
+				if err := c.checkRequiredParameters(ctx, "api", "tier"); err != nil {
+					return err
+				}
+				_, err = c.client.AddClientCertificate(wso2am.ClientCertificate{
+					Alias: alias,
+					APIID: ctx.String("api"),
+					Tier:  ctx.String("tier"),
+				}, data)
+				return err
+			}
+			if err := c.checkRequiredParameters(ctx, "endpoint"); err != nil {
+				return err
+			}
+			_, err = c.client.AddEndpointCertificate(wso2am.EndpointCertificate{
+				Alias:    alias,
+				Endpoint: ctx.String("endpoint"),
+			}, data)
+			return err
+		},
+	}
+}
+
+func (c *CLI) certificateInspect() cli.Command {
+	return cli.Command{
+		Name:      "inspect",
+		Aliases:   []string{"show", "cat"},
+		Usage:     "Inspect the subject, issuer and validity of the certificate",
+		ArgsUsage: "ALIAS",
+		Flags: []cli.Flag{
+			clientCertificateFlag,
+		},
+		Action: func(ctx *cli.Context) error {
+			if ctx.NArg() != 1 {
+				return errors.New("ALIAS is required")
+			}
+			var detail *wso2am.CertificateDetail
+			var err error
+			if ctx.Bool("client") {
+				detail, err = c.client.ClientCertificateDetail(ctx.Args().First())
+			} else {
+				detail, err = c.client.EndpointCertificateDetail(ctx.Args().First())
+			}
+			if err != nil {
+				return err
+			}
+			return c.inspect(detail)
+		},
+	}
+}
+
+func (c *CLI) certificateUpdate() cli.Command {
+	return cli.Command{
+		Name:      "update",
+		Usage:     "Replace the certificate, or change the tier of the client certificate",
+		ArgsUsage: "ALIAS [FILE]",
+		Flags: []cli.Flag{
+			clientCertificateFlag,
+			cli.StringFlag{
+				Name:  "tier",
+				Usage: "Throttling tier of the client (client certificates only)",
+			},
+		},
+		Action: func(ctx *cli.Context) error {
+			if ctx.NArg() < 1 || ctx.NArg() > 2 {
+				return errors.New("ALIAS is required")
+			}
+			alias := ctx.Args().Get(0)
+			var data []byte
+			if ctx.NArg() == 2 {
+				d, _, err := wso2am.ReadCertificateFile(ctx.Args().Get(1))
+				if err != nil {
+					return err
+				}
+				data = d
+			}
+			if ctx.Bool("client") {
+				if data == nil && ctx.String("tier") == "" {
+					return errors.New("FILE or --tier is required")
+				}
+				_, err := c.client.UpdateClientCertificate(alias, data, ctx.String("tier"))
+				return err
+			}
+			if data == nil {
+				return errors.New("FILE is required")
+			}
+			_, err := c.client.UpdateEndpointCertificate(alias, data)
+			return err
+		},
+	}
+}
+
+func (c *CLI) certificateDelete() cli.Command {
+	return cli.Command{
+		Name:      "delete",
+		Aliases:   []string{"del", "rm"},
+		Usage:     "Delete the certificates",
+		ArgsUsage: "ALIAS...",
+		Flags: []cli.Flag{
+			clientCertificateFlag,
+		},
+		Action: func(ctx *cli.Context) error {
+			if ctx.NArg() == 0 {
+				return errors.New("ALIAS is required")
+			}
+			for _, alias := range ctx.Args() {
+				var err error
+				if ctx.Bool("client") {
+					err = c.client.DeleteClientCertificate(alias)
+				} else {
+					err = c.client.DeleteEndpointCertificate(alias)
+				}
+				if err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+}
+
+func (c *CLI) certificateExpiring() cli.Command {
+	return cli.Command{
+		Name:  "expiring",
+		Usage: "Report the endpoint and client certificates which expire within the days",
+		Description: `Report the endpoint and client certificates which expire within the days, including the expired ones.
+Exits with non-zero status if any certificate is reported.  The certificates which cannot be checked are reported with the errors.`,
+		Flags: []cli.Flag{
+			cli.IntFlag{
+				Name:  "days,d",
+				Value: 30,
+			},
+			formatFlag,
+		},
+		Action: func(ctx *cli.Context) error {
+			format, err := outputFormat(ctx)
+			if err != nil {
+				return err
+			}
+			days := ctx.Int("days")
+			if days < 0 {
+				return errors.New("days must not be negative")
+			}
+			report, err := c.client.ExpiringCertificates(time.Duration(days) * 24 * time.Hour)
+			if err != nil {
+				return err
+			}
+			if format == formatJSON {
+				if err := c.inspect(report); err != nil {
+					return err
+				}
+			} else {
+				f := newTableFormatter()
+				f.Header("Kind", "Alias", "Endpoint/API", "NotAfter", "DaysLeft", "Subject")
+				for _, e := range report {
+					target := e.Endpoint
+					if e.Kind == wso2am.CertificateKindClient {
+						target = e.APIID
+					}
+					if e.Error != "" {
+						f.Row(e.Kind, e.Alias, target, "-", "-", "error: "+e.Error)
+						continue
+					}
+					daysLeft := int(time.Until(e.NotAfter).Hours() / 24)
+					f.Row(e.Kind, e.Alias, target, e.NotAfter.Format(certificateTimeFormat), daysLeft, e.Subject)
+				}
+				f.Flush()
+			}
+			if len(report) > 0 {
+				return cli.NewExitError(fmt.Sprintf("%d certificate(s) expire within %d days or cannot be checked", len(report), days), 1)
+			}
+			return nil
+		},
+	}
+}
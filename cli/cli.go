@@ -13,8 +13,12 @@ const Version = "0.0.11"
 type CLI struct {
 	app    *cli.App
 	client *wso2am.Client
+	config *wso2am.Config
 }
 
+// offlineCommands are the commands which work without connecting to the server.
+var offlineCommands = []string{"swagger", "help", "h"}
+
 func New() *CLI {
 	app := cli.NewApp()
 	app.Version = Version
@@ -71,27 +75,54 @@ func New() *CLI {
 		password := ctx.String("password")
 		clientName := ctx.String("client")
 		apiVersion := ctx.String("apiversion")
-		client, err := wso2am.New(&wso2am.Config{
+		c.config = &wso2am.Config{
 			EndpointCarbon: carbonURL,
 			EndpointToken:  tokenURL,
 			ClientName:     clientName,
 			UserName:       user,
 			Password:       password,
 			APIVersion:     apiVersion,
-		})
-		if err != nil {
-			return err
 		}
-		c.client = client
-		return nil
+		for _, cmd := range offlineCommands {
+			if ctx.Args().First() == cmd {
+				return nil
+			}
+		}
+		return c.connect()
 	}
 
 	c.addCommand(c.api())
+	c.addCommand(c.product())
 	c.addCommand(c.subscription())
+	c.addCommand(c.backup())
+	c.addCommand(c.restore())
+	c.addCommand(c.swagger())
+	c.addCommand(c.certificate())
+	c.addCommand(c.tier())
+	c.addCommand(c.env())
+	c.addCommand(c.label())
+	c.addCommand(c.store())
+	c.addCommand(c.application())
+	c.addCommand(c.policy())
+	c.addCommand(c.deny())
+	c.addCommand(c.workflow())
 
 	return c
 }
 
+// connect creates the client if not created yet.
+func (c *CLI) connect() error {
+	if c.client != nil {
+		return nil
+	}
+	client, err := wso2am.New(c.config)
+	if err != nil {
+		return err
+	}
+	c.client = client
+	return nil
+}
+
 func (c *CLI) addCommand(cmd cli.Command) {
 	c.app.Commands = append(c.app.Commands, cmd)
 }
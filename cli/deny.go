@@ -0,0 +1,165 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	wso2am "github.com/uphy/go-wso2am"
+	"github.com/urfave/cli"
+)
+
+func (c *CLI) deny() cli.Command {
+	return cli.Command{
+		Name:    "deny",
+		Aliases: []string{"blacklist"},
+		Usage:   "Deny policy (blacklist) management command",
+		Subcommands: cli.Commands{
+			c.denyList(),
+			c.denyAdd(),
+			c.denyEnable(true),
+			c.denyEnable(false),
+			c.denyRemove(),
+		},
+	}
+}
+
+func (c *CLI) denyList() cli.Command {
+	return cli.Command{
+		Name:    "list",
+		Aliases: []string{"ls", "dir"},
+		Usage:   "List the deny policies",
+		Flags: []cli.Flag{
+			cli.StringFlag{
+				Name:  "type",
+				Usage: "List the deny policies of the condition type",
+			},
+			formatFlag,
+		},
+		Action: func(ctx *cli.Context) error {
+			format, err := outputFormat(ctx)
+			if err != nil {
+				return err
+			}
+			var conditionType wso2am.BlockingConditionType
+			if ctx.IsSet("type") {
+				conditionType, err = wso2am.ParseBlockingConditionType(ctx.String("type"))
+				if err != nil {
+					return err
+				}
+			}
+			conditions, err := c.client.BlockingConditions()
+			if err != nil {
+				return err
+			}
+			filtered := []wso2am.BlockingCondition{}
+			for _, b := range conditions {
+				if conditionType == "" || b.Type == conditionType {
+					filtered = append(filtered, b)
+				}
+			}
+			if format == formatJSON {
+				return c.inspect(filtered)
+			}
+			f := newTableFormatter()
+			f.Header("ID", "Type", "Value", "Enabled")
+			for _, b := range filtered {
+				f.Row(b.ID, b.Type, b.ValueString(), b.Enabled)
+			}
+			f.Flush()
+			return nil
+		},
+	}
+}
+
+func (c *CLI) denyAdd() cli.Command {
+	types := []string{}
+	for _, t := range wso2am.BlockingConditionTypes {
+		types = append(types, strings.ToLower(string(t)))
+	}
+	return cli.Command{
+		Name:      "add",
+		Aliases:   []string{"create", "new"},
+		Usage:     "Add the deny policy",
+		ArgsUsage: "TYPE VALUE",
+		Description: fmt.Sprintf(`Block the requests at the gateway.  TYPE is one of %s, and VALUE is
+  api:         API context (e.g. /pizzashack/1.0.0)
+  application: OWNER:NAME of the application
+  ip:          IP address
+  iprange:     START-END or CIDR (e.g. 192.168.0.0/24)
+  user:        user name`, strings.Join(types, ", ")),
+		Flags: []cli.Flag{
+			cli.BoolFlag{
+				Name:  "invert",
+				Usage: "Block the requests from outside of the IP or the IP range",
+			},
+			cli.BoolFlag{
+				Name:  "disabled",
+				Usage: "Add the deny policy without enabling it",
+			},
+		},
+		Action: func(ctx *cli.Context) error {
+			if ctx.NArg() != 2 {
+				return errors.New("TYPE and VALUE are required")
+			}
+			conditionType, err := wso2am.ParseBlockingConditionType(ctx.Args().Get(0))
+			if err != nil {
+				return err
+			}
+			condition, err := wso2am.NewBlockingCondition(conditionType, ctx.Args().Get(1), ctx.Bool("invert"))
+			if err != nil {
+				return err
+			}
+			condition.Enabled = !ctx.Bool("disabled")
+			created, err := c.client.CreateBlockingCondition(condition)
+			if err != nil {
+				return err
+			}
+			fmt.Println(created.ID)
+			return nil
+		},
+	}
+}
+
+func (c *CLI) denyEnable(enabled bool) cli.Command {
+	name, usage := "enable", "Enable the deny policies"
+	if !enabled {
+		name, usage = "disable", "Disable the deny policies"
+	}
+	return cli.Command{
+		Name:      name,
+		Usage:     usage,
+		ArgsUsage: "ID...",
+		Action: func(ctx *cli.Context) error {
+			if ctx.NArg() == 0 {
+				return errors.New("ID is required")
+			}
+			for _, id := range ctx.Args() {
+				if _, err := c.client.SetBlockingConditionEnabled(id, enabled); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+}
+
+func (c *CLI) denyRemove() cli.Command {
+	return cli.Command{
+		Name:      "remove",
+		Aliases:   []string{"delete", "del", "rm"},
+		Usage:     "Remove the deny policies",
+		ArgsUsage: "ID...",
+		Action: func(ctx *cli.Context) error {
+			if ctx.NArg() == 0 {
+				return errors.New("ID is required")
+			}
+			for _, id := range ctx.Args() {
+				if err := c.client.DeleteBlockingCondition(id); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+}
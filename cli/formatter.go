@@ -5,6 +5,13 @@ import (
 	"fmt"
 	"os"
 	"text/tabwriter"
+
+	"github.com/urfave/cli"
+)
+
+const (
+	formatTable = "table"
+	formatJSON  = "json"
 )
 
 type TableFormatter struct {
@@ -37,3 +44,19 @@ func (f *TableFormatter) Row(v ...interface{}) {
 func (f *TableFormatter) Flush() {
 	f.w.Flush()
 }
+
+// formatFlag is the flag to choose the output format of the command.
+var formatFlag = cli.StringFlag{
+	Name:  "format",
+	Usage: fmt.Sprintf("Output format (%s or %s)", formatTable, formatJSON),
+	Value: formatTable,
+}
+
+func outputFormat(ctx *cli.Context) (string, error) {
+	switch f := ctx.String("format"); f {
+	case formatTable, formatJSON:
+		return f, nil
+	default:
+		return "", fmt.Errorf("unsupported format: %s", f)
+	}
+}
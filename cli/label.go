@@ -0,0 +1,159 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	wso2am "github.com/uphy/go-wso2am"
+	"github.com/urfave/cli"
+)
+
+func (c *CLI) label() cli.Command {
+	return cli.Command{
+		Name:  "label",
+		Usage: "Microgateway label management command",
+		Subcommands: cli.Commands{
+			c.labelList(),
+			c.labelCreate(),
+			c.labelUpdate(),
+			c.labelDelete(),
+		},
+	}
+}
+
+func (c *CLI) labelList() cli.Command {
+	return cli.Command{
+		Name:    "list",
+		Aliases: []string{"ls", "dir"},
+		Usage:   "List the microgateway labels",
+		Flags: []cli.Flag{
+			formatFlag,
+		},
+		Action: func(ctx *cli.Context) error {
+			format, err := outputFormat(ctx)
+			if err != nil {
+				return err
+			}
+			labels, err := c.client.Labels()
+			if err != nil {
+				return err
+			}
+			if format == formatJSON {
+				return c.inspect(labels)
+			}
+			f := newTableFormatter()
+			f.Header("ID", "Name", "AccessURLs", "Description")
+			for _, l := range labels {
+				f.Row(l.ID, l.Name, strings.Join(l.AccessURLs, ","), l.Description)
+			}
+			f.Flush()
+			return nil
+		},
+	}
+}
+
+func (c *CLI) labelCreate() cli.Command {
+	return cli.Command{
+		Name:      "create",
+		Aliases:   []string{"new"},
+		Usage:     "Create the microgateway label",
+		ArgsUsage: "NAME",
+		Flags: []cli.Flag{
+			cli.StringFlag{
+				Name: "description",
+			},
+			cli.StringSliceFlag{
+				Name:  "access-url",
+				Usage: "URL of the microgateway",
+			},
+		},
+		Action: func(ctx *cli.Context) error {
+			if ctx.NArg() != 1 {
+				return errors.New("NAME is required")
+			}
+			if err := c.checkRequiredParameters(ctx, "access-url"); err != nil {
+				return err
+			}
+			label, err := c.client.CreateLabel(&wso2am.Label{
+				Name:        ctx.Args().First(),
+				Description: ctx.String("description"),
+				AccessURLs:  ctx.StringSlice("access-url"),
+			})
+			if err != nil {
+				return err
+			}
+			fmt.Println(label.ID)
+			return nil
+		},
+	}
+}
+
+func (c *CLI) labelUpdate() cli.Command {
+	return cli.Command{
+		Name:      "update",
+		Usage:     "Update the description and the access URLs of the microgateway label",
+		ArgsUsage: "NAME",
+		Flags: []cli.Flag{
+			cli.StringFlag{
+				Name: "description",
+			},
+			cli.StringSliceFlag{
+				Name:  "access-url",
+				Usage: "URL of the microgateway (replaces the current URLs)",
+			},
+		},
+		Action: func(ctx *cli.Context) error {
+			if ctx.NArg() != 1 {
+				return errors.New("NAME is required")
+			}
+			label, err := c.findLabel(ctx.Args().First())
+			if err != nil {
+				return err
+			}
+			if ctx.IsSet("description") {
+				label.Description = ctx.String("description")
+			}
+			if ctx.IsSet("access-url") {
+				label.AccessURLs = ctx.StringSlice("access-url")
+			}
+			_, err = c.client.UpdateLabel(label)
+			return err
+		},
+	}
+}
+
+func (c *CLI) labelDelete() cli.Command {
+	return cli.Command{
+		Name:      "delete",
+		Aliases:   []string{"del", "rm"},
+		Usage:     "Delete the microgateway labels",
+		ArgsUsage: "NAME...",
+		Action: func(ctx *cli.Context) error {
+			if ctx.NArg() == 0 {
+				return errors.New("NAME is required")
+			}
+			for _, name := range ctx.Args() {
+				label, err := c.findLabel(name)
+				if err != nil {
+					return err
+				}
+				if err := c.client.DeleteLabel(label.ID); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+}
+
+func (c *CLI) findLabel(name string) (*wso2am.Label, error) {
+	label, err := c.client.FindLabel(name)
+	if err != nil {
+		return nil, err
+	}
+	if label == nil {
+		return nil, fmt.Errorf("label not found: %s", name)
+	}
+	return label, nil
+}
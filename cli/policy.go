@@ -0,0 +1,220 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	wso2am "github.com/uphy/go-wso2am"
+	"github.com/urfave/cli"
+)
+
+func (c *CLI) policy() cli.Command {
+	return cli.Command{
+		Name:  "policy",
+		Usage: "Throttling policy management command",
+		Subcommands: cli.Commands{
+			c.policyList(),
+			c.policyInspect(),
+			c.policyApply(),
+			c.policyDelete(),
+		},
+	}
+}
+
+// policyLevelFlag returns the flag of the policy level.
+func policyLevelFlag(usage string) cli.StringFlag {
+	levels := []string{}
+	for _, l := range wso2am.PolicyLevels {
+		levels = append(levels, string(l))
+	}
+	return cli.StringFlag{
+		Name:  "level",
+		Usage: fmt.Sprintf("%s (%s)", usage, strings.Join(levels, ", ")),
+		Value: string(wso2am.PolicyLevelAdvanced),
+	}
+}
+
+func (c *CLI) policyList() cli.Command {
+	return cli.Command{
+		Name:    "list",
+		Aliases: []string{"ls", "dir"},
+		Usage:   "List the throttling policies",
+		Flags: []cli.Flag{
+			policyLevelFlag("Policy level"),
+			formatFlag,
+		},
+		Action: func(ctx *cli.Context) error {
+			format, err := outputFormat(ctx)
+			if err != nil {
+				return err
+			}
+			level, err := wso2am.ParsePolicyLevel(ctx.String("level"))
+			if err != nil {
+				return err
+			}
+			policies, err := c.client.ThrottlePolicies(level)
+			if err != nil {
+				return err
+			}
+			if format == formatJSON {
+				return c.inspect(policies)
+			}
+			f := newTableFormatter()
+			f.Header("ID", "Name", "DisplayName", "Limit", "Deployed", "Description")
+			for _, p := range policies {
+				f.Row(p.ID, p.Name, p.DisplayName, p.Limit(), p.Deployed, p.Description)
+			}
+			f.Flush()
+			return nil
+		},
+	}
+}
+
+func (c *CLI) policyInspect() cli.Command {
+	return cli.Command{
+		Name:      "inspect",
+		Aliases:   []string{"show", "cat"},
+		Usage:     "Inspect the throttling policy",
+		ArgsUsage: "NAME",
+		Flags: []cli.Flag{
+			policyLevelFlag("Policy level"),
+		},
+		Action: func(ctx *cli.Context) error {
+			if ctx.NArg() != 1 {
+				return errors.New("NAME is required")
+			}
+			policy, err := c.findPolicy(ctx.String("level"), ctx.Args().First())
+			if err != nil {
+				return err
+			}
+			return c.inspect(policy)
+		},
+	}
+}
+
+func (c *CLI) policyApply() cli.Command {
+	// no default level not to create the policies of an unexpected level
+	levelFlag := policyLevelFlag("Level of the policies which do not have \"level\"")
+	levelFlag.Value = ""
+	return cli.Command{
+		Name:      "apply",
+		Usage:     "Create or update the throttling policies defined in the files",
+		ArgsUsage: "FILE...",
+		Description: `Create the policies defined in the YAML or JSON files, or update the policies of the same names.
+The files have the fields of the admin API and "level" for the level of the policy, which is required unless --level is given, e.g.
+
+  level: advanced
+  policyName: 10KPerMin
+  defaultLimit:
+    type: RequestCountLimit
+    timeUnit: min
+    unitTime: 1
+    requestCount: 10000
+  conditionalGroups:
+  - conditions:
+    - type: HeaderCondition
+      headerName: X-Partner
+      headerValue: internal
+    limit:
+      type: BandwidthLimit
+      timeUnit: min
+      unitTime: 1
+      dataAmount: 100
+      dataUnit: MB`,
+		Flags: []cli.Flag{
+			levelFlag,
+			cli.BoolFlag{
+				Name:  "dry-run",
+				Usage: "Validate the files without applying them",
+			},
+		},
+		Action: func(ctx *cli.Context) error {
+			if ctx.NArg() == 0 {
+				return errors.New("FILE is required")
+			}
+			var level wso2am.PolicyLevel
+			if ctx.IsSet("level") {
+				l, err := wso2am.ParsePolicyLevel(ctx.String("level"))
+				if err != nil {
+					return err
+				}
+				level = l
+			}
+			policies := []*wso2am.ThrottlePolicy{}
+			for _, file := range ctx.Args() {
+				policy, err := wso2am.ReadThrottlePolicyFile(file)
+				if err != nil {
+					return err
+				}
+				if policy.Level == "" {
+					if level == "" {
+						return fmt.Errorf("%s: level is required in the file or by --level", file)
+					}
+					policy.Level = level
+				}
+				if err := policy.Validate(); err != nil {
+					return fmt.Errorf("%s: %v", file, err)
+				}
+				policies = append(policies, policy)
+			}
+			if ctx.Bool("dry-run") {
+				return nil
+			}
+			for _, policy := range policies {
+				result, created, err := c.client.ApplyThrottlePolicy(policy)
+				if err != nil {
+					return err
+				}
+				action := "updated"
+				if created {
+					action = "created"
+				}
+				fmt.Printf("%s %s policy %s (%s)\n", action, result.Level, result.Name, result.ID)
+			}
+			return nil
+		},
+	}
+}
+
+func (c *CLI) policyDelete() cli.Command {
+	return cli.Command{
+		Name:      "delete",
+		Aliases:   []string{"del", "rm"},
+		Usage:     "Delete the throttling policies",
+		ArgsUsage: "NAME...",
+		Flags: []cli.Flag{
+			policyLevelFlag("Policy level"),
+		},
+		Action: func(ctx *cli.Context) error {
+			if ctx.NArg() == 0 {
+				return errors.New("NAME is required")
+			}
+			for _, name := range ctx.Args() {
+				policy, err := c.findPolicy(ctx.String("level"), name)
+				if err != nil {
+					return err
+				}
+				if err := c.client.DeleteThrottlePolicy(policy.Level, policy.ID); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+}
+
+func (c *CLI) findPolicy(level string, name string) (*wso2am.ThrottlePolicy, error) {
+	l, err := wso2am.ParsePolicyLevel(level)
+	if err != nil {
+		return nil, err
+	}
+	policy, err := c.client.FindThrottlePolicy(l, name)
+	if err != nil {
+		return nil, err
+	}
+	if policy == nil {
+		return nil, fmt.Errorf("%s policy not found: %s", l, name)
+	}
+	return policy, nil
+}
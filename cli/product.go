@@ -0,0 +1,344 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	multierror "github.com/hashicorp/go-multierror"
+	wso2am "github.com/uphy/go-wso2am"
+	"github.com/urfave/cli"
+)
+
+func (c *CLI) product() cli.Command {
+	return cli.Command{
+		Name:    "product",
+		Aliases: []string{"p"},
+		Usage:   "API Product management command",
+		Subcommands: cli.Commands{
+			c.productList(),
+			c.productChangeStatus(),
+			c.productDelete(),
+			c.productInspect(),
+			c.productSwagger(),
+			c.productUploadThumbnail(),
+			c.productThumbnail(),
+			c.productCreate(true),
+			c.productCreate(false),
+		},
+	}
+}
+
+func (c *CLI) productList() cli.Command {
+	return cli.Command{
+		Name:    "list",
+		Aliases: []string{"ls", "dir"},
+		Usage:   "List API Products",
+		Flags: []cli.Flag{
+			cli.StringFlag{
+				Name:  "query,q",
+				Value: "",
+			},
+		},
+		Action: func(ctx *cli.Context) error {
+			var query = ctx.String("query")
+			return list(func(entryc chan<- interface{}, errc chan<- error, done <-chan struct{}) {
+				c.client.SearchAPIProductsRaw(query, entryc, errc, done)
+			}, func(table *TableFormatter) {
+				table.Header("ID", "Name", "Context", "State", "Provider")
+			}, func(entry interface{}, table *TableFormatter) {
+				p := c.client.ConvertToAPIProduct(entry)
+				table.Row(p.ID, p.Name, p.Context, p.State, p.Provider)
+			})
+		},
+	}
+}
+
+func (c *CLI) productChangeStatus() cli.Command {
+	return cli.Command{
+		Name:  "change-status",
+		Usage: "Change API Product status",
+		Description: fmt.Sprintf(`Change API Product status.
+
+Available actions are:
+- %s
+- %s
+- %s
+- %s
+- %s
+`, wso2am.APIActionPublish, wso2am.APIActionDemoteToCreated, wso2am.APIActionBlock, wso2am.APIActionDeprecate, wso2am.APIActionRetire),
+		ArgsUsage: "ID ACTION",
+		Action: func(ctx *cli.Context) error {
+			if ctx.NArg() != 2 {
+				return errors.New("ID and ACTION are required")
+			}
+			return c.client.ChangeAPIProductStatus(ctx.Args().Get(0), wso2am.APIAction(ctx.Args().Get(1)))
+		},
+	}
+}
+
+func (c *CLI) productDelete() cli.Command {
+	return cli.Command{
+		Name:      "delete",
+		Aliases:   []string{"del", "rm"},
+		Usage:     "Delete the API Products",
+		ArgsUsage: "ID...",
+		Action: func(ctx *cli.Context) error {
+			if ctx.NArg() == 0 {
+				return errors.New("ID is required")
+			}
+			var errs error
+			for _, id := range ctx.Args() {
+				if err := c.client.DeleteAPIProduct(id); err != nil {
+					errs = multierror.Append(errs, err)
+					fmt.Println(err)
+				} else {
+					fmt.Println(id)
+				}
+			}
+			return errs
+		},
+	}
+}
+
+func (c *CLI) productInspect() cli.Command {
+	return cli.Command{
+		Name:      "inspect",
+		Aliases:   []string{"show", "cat"},
+		Usage:     "Inspect the API Product",
+		ArgsUsage: "ID",
+		Action: func(ctx *cli.Context) error {
+			if ctx.NArg() != 1 {
+				return errors.New("ID is required")
+			}
+			p, err := c.client.APIProduct(ctx.Args().First())
+			if err != nil {
+				return err
+			}
+			return c.inspect(p)
+		},
+	}
+}
+
+func (c *CLI) productSwagger() cli.Command {
+	return cli.Command{
+		Name:      "swagger",
+		Usage:     "Inspect the API definition of the API Product",
+		ArgsUsage: "ID",
+		Action: func(ctx *cli.Context) error {
+			if ctx.NArg() != 1 {
+				return errors.New("ID is required")
+			}
+			def, err := c.client.APIProductDefinition(ctx.Args().First())
+			if err != nil {
+				return err
+			}
+			return c.inspect(def)
+		},
+	}
+}
+
+func (c *CLI) productThumbnail() cli.Command {
+	return cli.Command{
+		Name:      "thumbnail",
+		Usage:     "Download the thumbnail",
+		ArgsUsage: "ID",
+		Flags:     thumbnailDownloadFlags,
+		Action: func(ctx *cli.Context) error {
+			if ctx.NArg() != 1 {
+				return errors.New("ID is required")
+			}
+			return c.downloadThumbnail(ctx, func(w io.Writer) (string, error) {
+				return c.client.APIProductThumbnail(ctx.Args().First(), w)
+			})
+		},
+	}
+}
+
+func (c *CLI) productUploadThumbnail() cli.Command {
+	return cli.Command{
+		Name:      "upload-thumbnail",
+		Usage:     "Upload the thumbnail",
+		ArgsUsage: "ID FILE",
+		Flags:     thumbnailUploadFlags,
+		Action: func(ctx *cli.Context) error {
+			if ctx.NArg() != 2 {
+				return errors.New("ID and FILE are required")
+			}
+			img, err := c.readThumbnail(ctx, ctx.Args().Get(1))
+			if err != nil {
+				return err
+			}
+			_, err = c.client.UploadAPIProductThumbnail(ctx.Args().Get(0), img)
+			return err
+		},
+	}
+}
+
+func (c *CLI) productCreate(update bool) cli.Command {
+	var commandName string
+	var commandAliases []string
+	var commandUsage string
+	var commandArgsUsage string
+	flags := []cli.Flag{
+		cli.StringFlag{
+			Name: "name",
+		},
+		cli.StringFlag{
+			Name: "context",
+		},
+		cli.StringFlag{
+			Name: "description",
+		},
+		cli.StringSliceFlag{
+			Name:  "api",
+			Usage: "ID of the API whose resources are all included",
+		},
+		cli.StringSliceFlag{
+			Name:  "resource",
+			Usage: "Resource to include (API_ID:VERB:PATH)",
+		},
+		cli.StringSliceFlag{
+			Name:  "gateway-env",
+			Usage: "Gateway environment (see 'env list')",
+		},
+		cli.StringSliceFlag{
+			Name:  "tier",
+			Usage: "Subscription tier of the API Product (see 'tier list')",
+		},
+		cli.StringSliceFlag{
+			Name: "visible-role",
+		},
+		cli.BoolFlag{
+			Name: "publish,P",
+		},
+	}
+	if update {
+		commandName = "update"
+		commandUsage = "Update the API Product"
+		commandArgsUsage = "ID"
+	} else {
+		commandName = "create"
+		commandAliases = []string{"new"}
+		commandUsage = "Create the API Product"
+	}
+	return cli.Command{
+		Name:      commandName,
+		Aliases:   commandAliases,
+		Usage:     commandUsage,
+		ArgsUsage: commandArgsUsage,
+		Flags:     flags,
+		Action: func(ctx *cli.Context) error {
+			var product *wso2am.APIProductDetail
+			if update {
+				if ctx.NArg() != 1 {
+					return errors.New("ID is required")
+				}
+				for _, f := range []string{"name", "context"} {
+					if ctx.IsSet(f) {
+						return fmt.Errorf("cannot update %s", f)
+					}
+				}
+				p, err := c.client.APIProduct(ctx.Args().First())
+				if err != nil {
+					return err
+				}
+				product = p
+			} else {
+				if err := c.checkRequiredParameters(ctx, "name", "context"); err != nil {
+					return err
+				}
+				if !ctx.IsSet("api") && !ctx.IsSet("resource") {
+					return errors.New("--api or --resource is required")
+				}
+				product = c.client.NewAPIProduct()
+				product.Name = ctx.String("name")
+				product.Context = ctx.String("context")
+			}
+
+			if ctx.IsSet("description") {
+				product.Description = ctx.String("description")
+			}
+			if ctx.IsSet("api") || ctx.IsSet("resource") {
+				apis, err := c.productAPIs(ctx.StringSlice("api"), ctx.StringSlice("resource"))
+				if err != nil {
+					return err
+				}
+				product.APIs = apis
+			}
+			if ctx.IsSet("gateway-env") {
+				envs := ctx.StringSlice("gateway-env")
+				if err := c.client.ValidateGatewayEnvironments(strings.Join(envs, ",")); err != nil {
+					return err
+				}
+				product.GatewayEnvironments = envs
+			}
+			if ctx.IsSet("tier") {
+				if err := c.client.ValidateTiers(wso2am.TierLevelAPI, ctx.StringSlice("tier")); err != nil {
+					return err
+				}
+				product.Policies = ctx.StringSlice("tier")
+			}
+			if ctx.IsSet("visible-role") {
+				product.Visibility = wso2am.APIVisibilityRestricted
+				product.VisibleRoles = ctx.StringSlice("visible-role")
+			}
+
+			var res *wso2am.APIProductDetail
+			var err error
+			if update {
+				res, err = c.client.UpdateAPIProduct(product)
+			} else {
+				res, err = c.client.CreateAPIProduct(product)
+			}
+			if err != nil {
+				return err
+			}
+			if !update {
+				fmt.Println(res.ID)
+			}
+			if ctx.Bool("publish") {
+				return c.client.ChangeAPIProductStatus(res.ID, wso2am.APIActionPublish)
+			}
+			return nil
+		},
+	}
+}
+
+// productAPIs builds the APIs of the API Product from the API IDs and the API_ID:VERB:PATH resources.
+func (c *CLI) productAPIs(apiIDs []string, resources []string) ([]wso2am.ProductAPI, error) {
+	ids := []string{}
+	operations := map[string][]wso2am.APIOperation{}
+	for _, id := range apiIDs {
+		if _, ok := operations[id]; !ok {
+			ids = append(ids, id)
+		}
+		// nil operations include all the resources
+		operations[id] = nil
+	}
+	for _, r := range resources {
+		parts := strings.SplitN(r, ":", 3)
+		if len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+			return nil, fmt.Errorf("invalid resource %q (expected API_ID:VERB:PATH)", r)
+		}
+		id := parts[0]
+		ops, ok := operations[id]
+		if !ok {
+			ids = append(ids, id)
+		} else if ops == nil {
+			// all the resources are already included
+			continue
+		}
+		operations[id] = append(ops, wso2am.APIOperation{Verb: parts[1], Target: parts[2]})
+	}
+	apis := []wso2am.ProductAPI{}
+	for _, id := range ids {
+		api, err := c.client.NewProductAPI(id, operations[id]...)
+		if err != nil {
+			return nil, err
+		}
+		apis = append(apis, *api)
+	}
+	return apis, nil
+}
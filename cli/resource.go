@@ -0,0 +1,164 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	wso2am "github.com/uphy/go-wso2am"
+	"github.com/urfave/cli"
+)
+
+func (c *CLI) apiResources() cli.Command {
+	return cli.Command{
+		Name:    "resources",
+		Aliases: []string{"resource", "res"},
+		Usage:   "API resource management command",
+		Subcommands: cli.Commands{
+			c.apiResourcesList(),
+			c.apiResourcesUpdate(),
+			c.apiResourcesAddScope(),
+		},
+	}
+}
+
+func (c *CLI) apiResourcesList() cli.Command {
+	return cli.Command{
+		Name:      "list",
+		Aliases:   []string{"ls", "dir"},
+		Usage:     "List the resources of the API",
+		ArgsUsage: "ID",
+		Flags: []cli.Flag{
+			formatFlag,
+		},
+		Action: func(ctx *cli.Context) error {
+			if ctx.NArg() != 1 {
+				return errors.New("ID is required")
+			}
+			format, err := outputFormat(ctx)
+			if err != nil {
+				return err
+			}
+			resources, err := c.client.APIResources(ctx.Args().First())
+			if err != nil {
+				return err
+			}
+			if format == formatJSON {
+				return c.inspect(resources)
+			}
+			f := newTableFormatter()
+			f.Header("Verb", "Path", "AuthType", "ThrottlingTier", "Scope", "MediationScript")
+			for _, r := range resources {
+				mediation := ""
+				if r.MediationScript != "" {
+					mediation = fmt.Sprintf("(%d bytes)", len(r.MediationScript))
+				}
+				f.Row(r.Verb, r.Path, r.AuthType, r.ThrottlingTier, r.Scope, mediation)
+			}
+			f.Flush()
+			return nil
+		},
+	}
+}
+
+func (c *CLI) apiResourcesUpdate() cli.Command {
+	return cli.Command{
+		Name:  "update",
+		Usage: "Update the resource of the API",
+		Description: fmt.Sprintf(`Update the auth type, throttling tier, scope and mediation script of the resource.
+The resource is created if not exists.  Set an empty string to remove the value.
+
+Available auth types are:
+- %s`, strings.Join(wso2am.APIAuthTypes, "\n- ")),
+		ArgsUsage: "ID VERB PATH",
+		Flags: []cli.Flag{
+			cli.StringFlag{
+				Name: "auth-type",
+			},
+			cli.StringFlag{
+				Name: "tier",
+			},
+			cli.StringFlag{
+				Name: "scope",
+			},
+			cli.StringFlag{
+				Name:  "mediation-script",
+				Usage: "Mediation script file",
+			},
+		},
+		Action: func(ctx *cli.Context) error {
+			if ctx.NArg() != 3 {
+				return errors.New("ID, VERB and PATH are required")
+			}
+			id := ctx.Args().Get(0)
+			verb := ctx.Args().Get(1)
+			path := ctx.Args().Get(2)
+
+			// keep the current values which are not specified by the flags
+			resource := wso2am.APIResource{Verb: strings.ToUpper(verb), Path: path}
+			resources, err := c.client.APIResources(id)
+			if err != nil {
+				return err
+			}
+			for _, r := range resources {
+				if r.Verb == resource.Verb && r.Path == path {
+					resource = r
+				}
+			}
+			if ctx.IsSet("auth-type") {
+				resource.AuthType = ctx.String("auth-type")
+			}
+			if ctx.IsSet("tier") {
+				resource.ThrottlingTier = ctx.String("tier")
+			}
+			if ctx.IsSet("scope") {
+				resource.Scope = ctx.String("scope")
+			}
+			if ctx.IsSet("mediation-script") {
+				resource.MediationScript = ""
+				if file := ctx.String("mediation-script"); file != "" {
+					script, err := ioutil.ReadFile(file)
+					if err != nil {
+						return err
+					}
+					resource.MediationScript = string(script)
+				}
+			}
+			_, err = c.client.UpdateAPIResource(id, resource)
+			return err
+		},
+	}
+}
+
+func (c *CLI) apiResourcesAddScope() cli.Command {
+	return cli.Command{
+		Name:      "add-scope",
+		Usage:     "Add the scope to the API",
+		ArgsUsage: "ID KEY",
+		Flags: []cli.Flag{
+			cli.StringFlag{
+				Name:  "name",
+				Usage: "Scope name (default: KEY)",
+			},
+			cli.StringFlag{
+				Name: "description",
+			},
+			cli.StringSliceFlag{
+				Name:  "role",
+				Usage: "Role allowed to use the scope",
+			},
+		},
+		Action: func(ctx *cli.Context) error {
+			if ctx.NArg() != 2 {
+				return errors.New("ID and KEY are required")
+			}
+			return c.client.AddAPIScope(ctx.Args().Get(0), wso2am.APIScope{
+				Key:         ctx.Args().Get(1),
+				Name:        ctx.String("name"),
+				Description: ctx.String("description"),
+				Roles:       strings.Join(ctx.StringSlice("role"), ","),
+			})
+		},
+	}
+}
@@ -0,0 +1,158 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	wso2am "github.com/uphy/go-wso2am"
+	"github.com/urfave/cli"
+)
+
+func (c *CLI) store() cli.Command {
+	return cli.Command{
+		Name:  "store",
+		Usage: "Store (developer portal) command",
+		Subcommands: cli.Commands{
+			c.storeList(),
+			c.storeInspect(),
+			c.storeSwagger(),
+		},
+	}
+}
+
+func (c *CLI) storeList() cli.Command {
+	return cli.Command{
+		Name:    "list",
+		Aliases: []string{"ls", "dir"},
+		Usage:   "List the published APIs",
+		Flags: []cli.Flag{
+			cli.StringFlag{
+				Name:  "query,q",
+				Value: "",
+			},
+			cli.StringFlag{
+				Name:  "tag",
+				Usage: "List the APIs with the tag",
+			},
+		},
+		Action: func(ctx *cli.Context) error {
+			var query = ctx.String("query")
+			if ctx.IsSet("tag") {
+				if query != "" {
+					return errors.New("--query and --tag cannot be used together")
+				}
+				query = "tag:" + ctx.String("tag")
+			}
+			return list(func(entryc chan<- interface{}, errc chan<- error, done <-chan struct{}) {
+				c.client.SearchStoreAPIsRaw(query, entryc, errc, done)
+			}, func(table *TableFormatter) {
+				table.Header("ID", "Name", "Version", "Context", "Status", "Provider")
+			}, func(entry interface{}, table *TableFormatter) {
+				api := c.client.ConvertToStoreAPI(entry)
+				table.Row(api.ID, api.Name, api.Version, api.Context, api.Status, api.Provider)
+			})
+		},
+	}
+}
+
+func (c *CLI) storeInspect() cli.Command {
+	return cli.Command{
+		Name:      "inspect",
+		Aliases:   []string{"show", "cat"},
+		Usage:     "Inspect the published API",
+		ArgsUsage: "ID",
+		Flags: []cli.Flag{
+			cli.BoolFlag{
+				Name:  "documents,d",
+				Usage: "Show the documents of the API",
+			},
+			cli.BoolFlag{
+				Name:  "ratings,r",
+				Usage: "Show the ratings and the comments of the API",
+			},
+		},
+		Action: func(ctx *cli.Context) error {
+			if ctx.NArg() != 1 {
+				return errors.New("ID is required")
+			}
+			id := ctx.Args().First()
+			api, err := c.client.StoreAPI(id)
+			if err != nil {
+				return err
+			}
+			if !ctx.Bool("documents") && !ctx.Bool("ratings") {
+				return c.inspect(api)
+			}
+			v := map[string]interface{}{
+				"api": api,
+			}
+			if ctx.Bool("documents") {
+				docs, err := c.client.StoreDocuments(id)
+				if err != nil {
+					return err
+				}
+				v["documents"] = docs
+			}
+			if ctx.Bool("ratings") {
+				ratings, err := c.client.StoreRatings(id)
+				if err != nil {
+					return err
+				}
+				comments, err := c.client.StoreComments(id)
+				if err != nil {
+					return err
+				}
+				v["ratings"] = ratings
+				v["comments"] = comments
+			}
+			return c.inspect(v)
+		},
+	}
+}
+
+func (c *CLI) storeSwagger() cli.Command {
+	return cli.Command{
+		Name:      "swagger",
+		Usage:     "Inspect the API definition of the published API",
+		ArgsUsage: "ID",
+		Action: func(ctx *cli.Context) error {
+			if ctx.NArg() != 1 {
+				return errors.New("ID is required")
+			}
+			def, err := c.client.StoreAPIDefinition(ctx.Args().First())
+			if err != nil {
+				return err
+			}
+			return c.inspect(def)
+		},
+	}
+}
+
+// splitNameVersion splits NAME[:VERSION] of the API.
+func splitNameVersion(s string) (name, version string) {
+	if i := strings.LastIndex(s, ":"); i >= 0 {
+		return s[:i], s[i+1:]
+	}
+	return s, ""
+}
+
+// findStoreAPI finds the published API by the ID, or by NAME[:VERSION] if it is not a UUID.
+func (c *CLI) findStoreAPI(idOrName string) (*wso2am.StoreAPI, error) {
+	if wso2am.UUIDPattern.MatchString(idOrName) {
+		api, err := c.client.StoreAPI(idOrName)
+		if err != nil {
+			return nil, err
+		}
+		return &api.StoreAPI, nil
+	}
+	name, version := splitNameVersion(idOrName)
+	api, err := c.client.FindStoreAPI(name, version)
+	if err != nil {
+		return nil, err
+	}
+	if api == nil {
+		return nil, fmt.Errorf("API not found: %s", idOrName)
+	}
+	return api, nil
+}
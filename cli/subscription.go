@@ -2,7 +2,11 @@ package cli
 
 import (
 	"errors"
+	"fmt"
+	"os"
+	"strings"
 
+	multierror "github.com/hashicorp/go-multierror"
 	"github.com/uphy/go-wso2am"
 
 	"github.com/urfave/cli"
@@ -18,6 +22,10 @@ func (c *CLI) subscription() cli.Command {
 			c.subscriptionInspect(),
 			c.subscriptionBlock(),
 			c.subscriptionUnblock(),
+			c.subscriptionCreate(),
+			c.subscriptionDelete(),
+			c.subscriptionChangeTier(),
+			c.subscriptionMigrate(),
 		},
 	}
 }
@@ -28,16 +36,77 @@ func (c *CLI) subscriptionList() cli.Command {
 		Aliases:   []string{"ls", "dir"},
 		Usage:     "List subscriptions",
 		ArgsUsage: "[API ID]",
+		Flags: []cli.Flag{
+			cli.StringFlag{
+				Name:  "status",
+				Usage: "List the subscriptions of the status (e.g. UNBLOCKED, BLOCKED, PROD_ONLY_BLOCKED)",
+			},
+			cli.StringFlag{
+				Name:  "app",
+				Usage: "List the subscriptions of the application (ID or name)",
+			},
+			cli.StringFlag{
+				Name:  "tier",
+				Usage: "List the subscriptions of the tier",
+			},
+			cli.StringFlag{
+				Name:  "api-name",
+				Usage: "List the subscriptions to the APIs of the name",
+			},
+			cli.BoolFlag{
+				Name:  "summary",
+				Usage: "Show the number of the subscriptions by the API",
+			},
+			formatFlag,
+		},
 		Action: func(ctx *cli.Context) error {
-			id := ctx.Args().First()
-			return list(func(entryc chan<- interface{}, errc chan<- error, done <-chan struct{}) {
-				c.client.SubscriptionsByAPIRaw(id, entryc, errc, done)
-			}, func(table *TableFormatter) {
-				table.Header("ID", "ApplicationID", "APIID", "Status")
-			}, func(entry interface{}, table *TableFormatter) {
-				s := c.client.ConvertToSubscription(entry)
-				table.Row(s.ID, s.ApplicationID, s.APIIdentifier, s.Status)
+			format, err := outputFormat(ctx)
+			if err != nil {
+				return err
+			}
+			subscriptions, warnings, err := c.client.SubscriptionDetails(ctx.Args().First(), &wso2am.SubscriptionFilter{
+				Status:      ctx.String("status"),
+				Application: ctx.String("app"),
+				Tier:        ctx.String("tier"),
+				APIName:     ctx.String("api-name"),
 			})
+			if err != nil {
+				return err
+			}
+			for _, w := range warnings {
+				fmt.Fprintf(os.Stderr, "warning: %v\n", w)
+			}
+			if ctx.Bool("summary") {
+				summaries := wso2am.SummarizeSubscriptions(subscriptions)
+				if format == formatJSON {
+					return c.inspect(summaries)
+				}
+				f := newTableFormatter()
+				f.Header("APIID", "API", "Version", "Subscribers", "Unblocked", "Blocked", "ProdOnlyBlocked")
+				for _, s := range summaries {
+					f.Row(s.APIID, s.APIName, s.APIVersion, s.Total, s.Statuses[wso2am.SubscriptionStatusUnblocked], s.Statuses[string(wso2am.SubscriptionBlockStateBlocked)], s.Statuses[string(wso2am.SubscriptionBlockStateProdOnlyBlocked)])
+				}
+				f.Flush()
+				return nil
+			}
+			if format == formatJSON {
+				return c.inspect(subscriptions)
+			}
+			f := newTableFormatter()
+			f.Header("ID", "Application", "Owner", "API", "Version", "Tier", "Status")
+			for _, s := range subscriptions {
+				// the IDs are shown for the applications and the APIs which cannot be looked up
+				app, api := s.ApplicationName, s.APIName
+				if app == "" {
+					app = s.ApplicationID
+				}
+				if api == "" {
+					api = s.APIIdentifier
+				}
+				f.Row(s.ID, app, s.ApplicationOwner, api, s.APIVersion, s.Tier, s.Status)
+			}
+			f.Flush()
+			return nil
 		},
 	}
 }
@@ -62,17 +131,54 @@ func (c *CLI) subscriptionInspect() cli.Command {
 	}
 }
 
+// subscriptionSelectionFlags select the subscriptions of the bulk operations.
+var subscriptionSelectionFlags = []cli.Flag{
+	cli.StringFlag{
+		Name:  "app",
+		Usage: "Select the subscriptions of the application (ID or name)",
+	},
+	cli.StringFlag{
+		Name:  "api",
+		Usage: "Select the subscriptions to the API (ID or NAME:VERSION)",
+	},
+	cli.StringFlag{
+		Name:  "tier",
+		Usage: "Select the subscriptions of the tier",
+	},
+	cli.StringFlag{
+		Name:  "api-name",
+		Usage: "Select the subscriptions to the APIs of the name",
+	},
+	cli.IntFlag{
+		Name:  "parallel",
+		Usage: "Number of the subscriptions processed at once",
+		Value: wso2am.DefaultBulkParallelism,
+	},
+	cli.BoolFlag{
+		Name:  "dry-run",
+		Usage: "Show the subscriptions which would be changed without changing them",
+	},
+	formatFlag,
+}
+
 func (c *CLI) subscriptionBlock() cli.Command {
 	return cli.Command{
 		Name:  "block",
-		Usage: "Block the subscription",
-		Flags: []cli.Flag{
+		Usage: "Block the subscriptions",
+		Description: `Block the subscriptions specified by the IDs, or selected by the application, the API and the filters.
+The statuses before blocking are saved to the undo file when the subscriptions are selected by the flags or --undo is given,
+and 'subscription unblock --from-undo FILE' restores them.`,
+		Flags: append([]cli.Flag{
 			cli.BoolFlag{
 				Name:  "prodonly",
 				Usage: "Block production subscription only",
 			},
-		},
-		ArgsUsage: "ID",
+			cli.StringFlag{
+				Name:  "undo",
+				Usage: "Undo file to save (default: subscription-undo-TIMESTAMP.json for the selection flags)",
+			},
+		}, subscriptionSelectionFlags...),
+		ArgsUsage: "[ID...]",
 		Action: func(ctx *cli.Context) error {
 			var state wso2am.SubscriptionBlockState
 			if ctx.Bool("prodonly") {
@@ -80,34 +186,461 @@ func (c *CLI) subscriptionBlock() cli.Command {
 			} else {
 				state = wso2am.SubscriptionBlockStateBlocked
 			}
-			if ctx.NArg() != 1 {
-				return errors.New("ID is required")
+			format, err := outputFormat(ctx)
+			if err != nil {
+				return err
 			}
-			id := ctx.Args().Get(0)
-			_, err := c.client.BlockSubscription(id, state)
+			subscriptions, failures, err := c.selectSubscriptions(ctx)
 			if err != nil {
 				return err
 			}
-			return nil
+			dryRun := ctx.Bool("dry-run")
+			var undoFile string
+			if !dryRun && (ctx.IsSet("undo") || ctx.NArg() == 0) {
+				// the statuses are saved before the changes, so they can be restored even if the run is interrupted
+				plan := wso2am.NewSubscriptionUndo(c.client.BlockSubscriptions(subscriptions, state, ctx.Int("parallel"), true))
+				if len(plan.Entries) > 0 {
+					undoFile = ctx.String("undo")
+					if undoFile == "" {
+						undoFile = fmt.Sprintf("subscription-undo-%s.json", plan.Created.Format("20060102-150405"))
+					}
+					if err := writeSubscriptionUndo(undoFile, plan); err != nil {
+						return err
+					}
+					fmt.Fprintf(os.Stderr, "undo file: %s\n", undoFile)
+				}
+			}
+			results := append(c.client.BlockSubscriptions(subscriptions, state, ctx.Int("parallel"), dryRun), failures...)
+			var undoErr error
+			if undoFile != "" {
+				// rewrite the undo file with the subscriptions actually changed
+				undoErr = writeSubscriptionUndo(undoFile, wso2am.NewSubscriptionUndo(results))
+			}
+			// the results are shown even if the undo file cannot be written, because the subscriptions are already changed
+			if err := c.printBulkSubscriptionResults(format, results); err != nil && undoErr == nil {
+				return err
+			}
+			return undoErr
 		},
 	}
 }
 
+func writeSubscriptionUndo(file string, undo *wso2am.SubscriptionUndo) error {
+	if err := undo.WriteFile(file); err != nil {
+		return cli.NewExitError(fmt.Sprintf("failed to write the undo file: %v", err), 1)
+	}
+	return nil
+}
+
 func (c *CLI) subscriptionUnblock() cli.Command {
 	return cli.Command{
-		Name:      "unblock",
-		Usage:     "Unblock the subscription",
-		ArgsUsage: "ID",
+		Name:        "unblock",
+		Usage:       "Unblock the subscriptions",
+		Description: "Unblock the subscriptions specified by the IDs, or selected by the application, the API and the filters, or restore the statuses saved in the undo file of 'subscription block'.",
+		Flags: append([]cli.Flag{
+			cli.StringFlag{
+				Name:  "from-undo",
+				Usage: "Restore the statuses saved in the undo file",
+			},
+		}, subscriptionSelectionFlags...),
+		ArgsUsage: "[ID...]",
 		Action: func(ctx *cli.Context) error {
-			if ctx.NArg() != 1 {
-				return errors.New("ID is required")
+			format, err := outputFormat(ctx)
+			if err != nil {
+				return err
+			}
+			var results []wso2am.BulkSubscriptionResult
+			if ctx.IsSet("from-undo") {
+				if ctx.NArg() > 0 || c.hasSubscriptionSelection(ctx) {
+					return errors.New("--from-undo cannot be used with the IDs and the selection flags")
+				}
+				undo, err := wso2am.ReadSubscriptionUndoFile(ctx.String("from-undo"))
+				if err != nil {
+					return err
+				}
+				results = c.client.RestoreSubscriptions(undo, ctx.Int("parallel"), ctx.Bool("dry-run"))
+			} else {
+				subscriptions, failures, err := c.selectSubscriptions(ctx)
+				if err != nil {
+					return err
+				}
+				results = append(c.client.UnblockSubscriptions(subscriptions, ctx.Int("parallel"), ctx.Bool("dry-run")), failures...)
+			}
+			return c.printBulkSubscriptionResults(format, results)
+		},
+	}
+}
+
+func (c *CLI) hasSubscriptionSelection(ctx *cli.Context) bool {
+	for _, f := range []string{"app", "api", "tier", "api-name"} {
+		if ctx.IsSet(f) {
+			return true
+		}
+	}
+	return false
+}
+
+// selectSubscriptions returns the subscriptions of the IDs, or the ones selected by the flags,
+// and the failed results of the subscriptions which cannot be looked up.
+func (c *CLI) selectSubscriptions(ctx *cli.Context) ([]wso2am.SubscriptionDetail, []wso2am.BulkSubscriptionResult, error) {
+	if ctx.NArg() > 0 {
+		if c.hasSubscriptionSelection(ctx) {
+			return nil, nil, errors.New("ID cannot be used with --app, --api, --tier and --api-name")
+		}
+		resolver := wso2am.NewSubscriptionResolver(c.client)
+		subscriptions := []wso2am.SubscriptionDetail{}
+		failures := []wso2am.BulkSubscriptionResult{}
+		for _, id := range ctx.Args() {
+			s, err := c.client.Subscription(id)
+			if err != nil {
+				failures = append(failures, wso2am.NewFailedSubscriptionResult(&wso2am.Subscription{ID: id}, err))
+				continue
+			}
+			detail, err := resolver.Resolve(s)
+			if err != nil {
+				failures = append(failures, wso2am.NewFailedSubscriptionResult(s, err))
+				continue
+			}
+			subscriptions = append(subscriptions, *detail)
+		}
+		return subscriptions, failures, nil
+	}
+	if !c.hasSubscriptionSelection(ctx) {
+		return nil, nil, errors.New("ID, or --app, --api, --tier or --api-name is required")
+	}
+	var apiID string
+	if ctx.IsSet("api") {
+		id, err := c.publisherAPIID(ctx.String("api"))
+		if err != nil {
+			return nil, nil, err
+		}
+		apiID = id
+	}
+	return c.client.FindSubscriptionDetails(apiID, &wso2am.SubscriptionFilter{
+		Application: ctx.String("app"),
+		Tier:        ctx.String("tier"),
+		APIName:     ctx.String("api-name"),
+	})
+}
+
+func (c *CLI) printBulkSubscriptionResults(format string, results []wso2am.BulkSubscriptionResult) error {
+	failed := 0
+	for _, r := range results {
+		if r.Error != "" {
+			failed++
+		}
+	}
+	if format == formatJSON {
+		if err := c.inspect(results); err != nil {
+			return err
+		}
+	} else {
+		f := newTableFormatter()
+		f.Header("ID", "Application", "API", "Version", "Previous", "Status", "Changed", "Skipped", "Error")
+		for _, r := range results {
+			f.Row(r.ID, r.ApplicationName, r.APIName, r.APIVersion, r.PreviousStatus, r.Status, r.Changed, r.Skipped, r.Error)
+		}
+		f.Flush()
+	}
+	if failed > 0 {
+		return cli.NewExitError(fmt.Sprintf("%d of %d subscriptions failed", failed, len(results)), 1)
+	}
+	return nil
+}
+
+func (c *CLI) subscriptionCreate() cli.Command {
+	return cli.Command{
+		Name:        "create",
+		Aliases:     []string{"new"},
+		Usage:       "Subscribe the applications to the APIs",
+		Description: "Subscribe an application to the APIs, or the applications to an API.  The applications can be specified by the name, and the APIs by NAME[:VERSION].",
+		Flags: []cli.Flag{
+			cli.StringSliceFlag{
+				Name:  "app",
+				Usage: "ID or name of the application",
+			},
+			cli.StringSliceFlag{
+				Name:  "api",
+				Usage: "ID or NAME[:VERSION] of the API",
+			},
+			cli.StringFlag{
+				Name:  "tier",
+				Usage: "Subscription tier (see 'tier list')",
+				Value: "Unlimited",
+			},
+		},
+		Action: func(ctx *cli.Context) error {
+			if err := c.checkRequiredParameters(ctx, "app", "api"); err != nil {
+				return err
+			}
+			apps, apis := ctx.StringSlice("app"), ctx.StringSlice("api")
+			if len(apps) > 1 && len(apis) > 1 {
+				return errors.New("subscribe an application to the APIs, or the applications to an API")
+			}
+			appIDs := []string{}
+			for _, a := range apps {
+				app, err := c.findApplication(a)
+				if err != nil {
+					return err
+				}
+				appIDs = append(appIDs, app.ID)
+			}
+			apiIDs := []string{}
+			for _, a := range apis {
+				api, err := c.findStoreAPI(a)
+				if err != nil {
+					return err
+				}
+				apiIDs = append(apiIDs, api.ID)
+			}
+			tier := ctx.String("tier")
+			var subscriptions []wso2am.Subscription
+			var err error
+			switch {
+			case len(appIDs) == 1 && len(apiIDs) == 1:
+				var s *wso2am.Subscription
+				s, err = c.client.Subscribe(appIDs[0], apiIDs[0], tier)
+				if s != nil {
+					subscriptions = []wso2am.Subscription{*s}
+				}
+			case len(appIDs) == 1:
+				subscriptions, err = c.client.SubscribeAPIs(appIDs[0], apiIDs, tier)
+			default:
+				subscriptions, err = c.client.SubscribeApplications(apiIDs[0], appIDs, tier)
 			}
-			id := ctx.Args().Get(0)
-			_, err := c.client.UnblockSubscription(id)
 			if err != nil {
 				return err
 			}
+			for _, s := range subscriptions {
+				fmt.Println(s.ID)
+			}
 			return nil
 		},
 	}
 }
+
+func (c *CLI) subscriptionDelete() cli.Command {
+	return cli.Command{
+		Name:      "delete",
+		Aliases:   []string{"del", "rm"},
+		Usage:     "Unsubscribe",
+		ArgsUsage: "[ID...]",
+		Flags: []cli.Flag{
+			cli.StringFlag{
+				Name:  "app",
+				Usage: "ID or name of the application (with --api instead of the IDs)",
+			},
+			cli.StringFlag{
+				Name:  "api",
+				Usage: "ID or NAME[:VERSION] of the API (with --app instead of the IDs)",
+			},
+		},
+		Action: func(ctx *cli.Context) error {
+			ids := []string(ctx.Args())
+			if ctx.IsSet("app") || ctx.IsSet("api") {
+				if len(ids) > 0 {
+					return errors.New("ID cannot be used with --app and --api")
+				}
+				s, err := c.findSubscription(ctx.String("app"), ctx.String("api"))
+				if err != nil {
+					return err
+				}
+				ids = []string{s.ID}
+			}
+			if len(ids) == 0 {
+				return errors.New("ID, or --app and --api are required")
+			}
+			var errs error
+			for _, id := range ids {
+				if err := c.client.Unsubscribe(id); err != nil {
+					errs = multierror.Append(errs, err)
+					fmt.Println(err)
+				} else {
+					fmt.Println(id)
+				}
+			}
+			return errs
+		},
+	}
+}
+
+func (c *CLI) subscriptionChangeTier() cli.Command {
+	return cli.Command{
+		Name:        "change-tier",
+		Usage:       "Change the tier of the subscription",
+		Description: "Change the tier of the subscription.  The store API v0.x recreates the subscription, and its ID changes.",
+		ArgsUsage:   "[ID]",
+		Flags: []cli.Flag{
+			cli.StringFlag{
+				Name:  "tier",
+				Usage: "New subscription tier (see 'tier list')",
+			},
+			cli.StringFlag{
+				Name:  "app",
+				Usage: "ID or name of the application (with --api instead of the ID)",
+			},
+			cli.StringFlag{
+				Name:  "api",
+				Usage: "ID or NAME[:VERSION] of the API (with --app instead of the ID)",
+			},
+		},
+		Action: func(ctx *cli.Context) error {
+			if err := c.checkRequiredParameters(ctx, "tier"); err != nil {
+				return err
+			}
+			var id string
+			switch {
+			case ctx.NArg() == 1 && !ctx.IsSet("app") && !ctx.IsSet("api"):
+				id = ctx.Args().First()
+			case ctx.NArg() == 0 && (ctx.IsSet("app") || ctx.IsSet("api")):
+				s, err := c.findSubscription(ctx.String("app"), ctx.String("api"))
+				if err != nil {
+					return err
+				}
+				id = s.ID
+			default:
+				return errors.New("ID, or --app and --api are required")
+			}
+			s, err := c.client.ChangeSubscriptionTier(id, ctx.String("tier"))
+			if err != nil {
+				return err
+			}
+			fmt.Println(s.ID)
+			return nil
+		},
+	}
+}
+
+// findSubscription finds the subscription of the application to the API.
+func (c *CLI) findSubscription(appIDOrName, apiIDOrName string) (*wso2am.Subscription, error) {
+	if appIDOrName == "" || apiIDOrName == "" {
+		return nil, errors.New("both --app and --api are required")
+	}
+	app, err := c.findApplication(appIDOrName)
+	if err != nil {
+		return nil, err
+	}
+	api, err := c.findStoreAPI(apiIDOrName)
+	if err != nil {
+		return nil, err
+	}
+	s, err := c.client.FindSubscription(app.ID, api.ID)
+	if err != nil {
+		return nil, err
+	}
+	if s == nil {
+		return nil, fmt.Errorf("application %s does not subscribe API %s", app.Name, apiIDOrName)
+	}
+	return s, nil
+}
+
+func (c *CLI) subscriptionMigrate() cli.Command {
+	return cli.Command{
+		Name:  "migrate",
+		Usage: "Recreate the subscriptions of the old API version on the new version",
+		Description: `Recreate the active and the PROD_ONLY_BLOCKED subscriptions of the old API on the new API with the same applications, tiers and statuses.
+The applications which already subscribe the new API are skipped, so the migration can be re-run after failures.
+The subscriptions are created through the store API as the user, so the applications must be accessible to the user.`,
+		Flags: []cli.Flag{
+			cli.StringFlag{
+				Name:  "from",
+				Usage: "ID or NAME:VERSION of the old API",
+			},
+			cli.StringFlag{
+				Name:  "to",
+				Usage: "ID or NAME:VERSION of the new API",
+			},
+			cli.StringSliceFlag{
+				Name:  "tier-map",
+				Usage: "Tier of the new subscriptions for the tier of the old ones (OLD=NEW)",
+			},
+			cli.BoolFlag{
+				Name:  "block-old",
+				Usage: "Block the old subscriptions after migrating them",
+			},
+			cli.BoolFlag{
+				Name:  "dry-run",
+				Usage: "Report what would be done without changing anything",
+			},
+			formatFlag,
+		},
+		Action: func(ctx *cli.Context) error {
+			if err := c.checkRequiredParameters(ctx, "from", "to"); err != nil {
+				return err
+			}
+			format, err := outputFormat(ctx)
+			if err != nil {
+				return err
+			}
+			opts := &wso2am.SubscriptionMigrationOptions{
+				TierMapping: map[string]string{},
+				BlockOld:    ctx.Bool("block-old"),
+				DryRun:      ctx.Bool("dry-run"),
+			}
+			for _, m := range ctx.StringSlice("tier-map") {
+				kv := strings.SplitN(m, "=", 2)
+				if len(kv) != 2 || kv[0] == "" || kv[1] == "" {
+					return fmt.Errorf("invalid tier mapping %q (expected OLD=NEW)", m)
+				}
+				opts.TierMapping[kv[0]] = kv[1]
+			}
+			// the old API can be already BLOCKED or RETIRED, which is not in the store
+			from, err := c.publisherAPIID(ctx.String("from"))
+			if err != nil {
+				return err
+			}
+			to, err := c.storeAPIID(ctx.String("to"))
+			if err != nil {
+				return err
+			}
+			report, err := c.client.MigrateSubscriptions(from, to, opts)
+			if err != nil {
+				return err
+			}
+			if format == formatJSON {
+				if err := c.inspect(report); err != nil {
+					return err
+				}
+			} else {
+				f := newTableFormatter()
+				f.Header("ApplicationID", "OldTier", "NewTier", "Status", "Result", "NewSubscriptionID", "OldBlocked", "Error")
+				for _, e := range report.Entries {
+					f.Row(e.ApplicationID, e.OldTier, e.NewTier, e.OldStatus, e.Result, e.NewSubscriptionID, e.Blocked, e.Error)
+				}
+				f.Flush()
+			}
+			if n := report.Failed(); n > 0 {
+				return cli.NewExitError(fmt.Sprintf("%d of %d subscriptions failed to migrate", n, len(report.Entries)), 1)
+			}
+			return nil
+		},
+	}
+}
+
+// storeAPIID returns the ID as is, or finds the ID of the API by NAME[:VERSION].
+func (c *CLI) storeAPIID(idOrName string) (string, error) {
+	if wso2am.UUIDPattern.MatchString(idOrName) {
+		return idOrName, nil
+	}
+	api, err := c.findStoreAPI(idOrName)
+	if err != nil {
+		return "", err
+	}
+	return api.ID, nil
+}
+
+// publisherAPIID returns the ID as is, or finds the ID of the API by NAME[:VERSION] in the publisher.
+// Unlike storeAPIID, it finds the APIs which are not visible in the store, e.g. BLOCKED and RETIRED.
+func (c *CLI) publisherAPIID(idOrName string) (string, error) {
+	if wso2am.UUIDPattern.MatchString(idOrName) {
+		return idOrName, nil
+	}
+	api, err := c.client.FindAPI(splitNameVersion(idOrName))
+	if err != nil {
+		return "", err
+	}
+	if api == nil {
+		return "", fmt.Errorf("API not found: %s", idOrName)
+	}
+	return api.ID, nil
+}
@@ -0,0 +1,243 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	wso2am "github.com/uphy/go-wso2am"
+	"github.com/urfave/cli"
+)
+
+func (c *CLI) swagger() cli.Command {
+	return cli.Command{
+		Name:  "swagger",
+		Usage: "API definition utility command",
+		Subcommands: cli.Commands{
+			c.swaggerConvert(),
+			c.swaggerBundle(),
+			c.swaggerLint(),
+			c.swaggerDiff(),
+		},
+	}
+}
+
+func (c *CLI) swaggerConvert() cli.Command {
+	return cli.Command{
+		Name:      "convert",
+		Usage:     "Convert the API definition between Swagger 2.0 and OpenAPI 3",
+		ArgsUsage: "FILE",
+		Flags: append([]cli.Flag{
+			cli.StringFlag{
+				Name:  "to",
+				Usage: fmt.Sprintf("%s or %s", wso2am.APIDefinitionSpecSwagger2, wso2am.APIDefinitionSpecOpenAPI3),
+				Value: string(wso2am.APIDefinitionSpecSwagger2),
+			},
+		}, templateFlags...),
+		Action: func(ctx *cli.Context) error {
+			if ctx.NArg() != 1 {
+				return errors.New("FILE is required")
+			}
+			def, err := c.bundleAPIDefinition(ctx, ctx.Args().First())
+			if err != nil {
+				return err
+			}
+			converted, warnings, err := def.ConvertTo(wso2am.APIDefinitionSpec(ctx.String("to")))
+			if err != nil {
+				return err
+			}
+			printConversionWarnings(warnings)
+			return c.inspectAPIDefinition(converted)
+		},
+	}
+}
+
+func (c *CLI) swaggerBundle() cli.Command {
+	return cli.Command{
+		Name:      "bundle",
+		Usage:     "Resolve the external references and bundle the API definition into a single file",
+		ArgsUsage: "FILE",
+		Flags:     templateFlags,
+		Action: func(ctx *cli.Context) error {
+			if ctx.NArg() != 1 {
+				return errors.New("FILE is required")
+			}
+			def, err := c.bundleAPIDefinition(ctx, ctx.Args().First())
+			if err != nil {
+				return err
+			}
+			return c.inspectAPIDefinition(def)
+		},
+	}
+}
+
+func (c *CLI) swaggerLint() cli.Command {
+	return cli.Command{
+		Name:  "lint",
+		Usage: "Validate the API definition and the WSO2 extensions",
+		Description: `Validate the API definition conforms to Swagger 2.0 or OpenAPI 3, and the WSO2 extensions
+(x-auth-type, x-throttling-tier, x-scope and x-wso2-security) are valid.
+
+Exits with non-zero status if the definition has errors.`,
+		ArgsUsage: "FILE",
+		Flags: append([]cli.Flag{
+			formatFlag,
+		}, templateFlags...),
+		Action: func(ctx *cli.Context) error {
+			if ctx.NArg() != 1 {
+				return errors.New("FILE is required")
+			}
+			format, err := outputFormat(ctx)
+			if err != nil {
+				return err
+			}
+			def, err := c.bundleAPIDefinition(ctx, ctx.Args().First())
+			if err != nil {
+				return err
+			}
+			issues, err := def.Lint()
+			if err != nil {
+				return err
+			}
+			if format == formatJSON {
+				if err := c.inspect(issues); err != nil {
+					return err
+				}
+			} else {
+				f := newTableFormatter()
+				f.Header("Severity", "Path", "Message")
+				for _, issue := range issues {
+					f.Row(issue.Severity, issue.Path, issue.Message)
+				}
+				f.Flush()
+			}
+			errs := 0
+			for _, issue := range issues {
+				if issue.Severity == wso2am.ValidationSeverityError {
+					errs++
+				}
+			}
+			if errs > 0 {
+				return cli.NewExitError(fmt.Sprintf("%d errors found", errs), 1)
+			}
+			return nil
+		},
+	}
+}
+
+func (c *CLI) swaggerDiff() cli.Command {
+	return cli.Command{
+		Name:  "diff",
+		Usage: "Detect the breaking changes between the API definitions",
+		Description: `Compare the API definitions and classify the changes into breaking and non-breaking.
+
+With --api, the current definition of the API is compared with NEW_FILE.
+Exits with non-zero status if breaking changes are found.`,
+		ArgsUsage: "[OLD_FILE] NEW_FILE",
+		Flags: append([]cli.Flag{
+			cli.StringFlag{
+				Name:  "api",
+				Usage: "ID of the API to compare with",
+			},
+			formatFlag,
+		}, templateFlags...),
+		Action: func(ctx *cli.Context) error {
+			format, err := outputFormat(ctx)
+			if err != nil {
+				return err
+			}
+			var changes []wso2am.APIDefinitionChange
+			if ctx.IsSet("api") {
+				if ctx.NArg() != 1 {
+					return errors.New("NEW_FILE is required")
+				}
+				if err := c.connect(); err != nil {
+					return err
+				}
+				newDef, err := c.bundleAPIDefinition(ctx, ctx.Args().Get(0))
+				if err != nil {
+					return err
+				}
+				changes, err = c.client.DiffAPIDefinition(ctx.String("api"), newDef)
+				if err != nil {
+					return err
+				}
+			} else {
+				if ctx.NArg() != 2 {
+					return errors.New("OLD_FILE and NEW_FILE are required")
+				}
+				oldDef, err := c.bundleAPIDefinition(ctx, ctx.Args().Get(0))
+				if err != nil {
+					return err
+				}
+				newDef, err := c.bundleAPIDefinition(ctx, ctx.Args().Get(1))
+				if err != nil {
+					return err
+				}
+				changes, err = wso2am.DiffAPIDefinitions(oldDef, newDef)
+				if err != nil {
+					return err
+				}
+			}
+			if format == formatJSON {
+				if err := c.inspect(changes); err != nil {
+					return err
+				}
+			} else {
+				printAPIDefinitionChanges(changes)
+			}
+			if wso2am.HasBreakingChanges(changes) {
+				return cli.NewExitError("breaking changes found", 1)
+			}
+			return nil
+		},
+	}
+}
+
+func printAPIDefinitionChanges(changes []wso2am.APIDefinitionChange) {
+	f := newTableFormatter()
+	f.Header("Breaking", "Operation", "Location", "Message")
+	for _, change := range changes {
+		f.Row(change.Breaking, change.Operation, change.Location, change.Message)
+	}
+	f.Flush()
+}
+
+// loadAPIDefinition loads the API definition file resolving the external references,
+// and converts it to the specification the server accepts.
+func (c *CLI) loadAPIDefinition(ctx *cli.Context, file string) (wso2am.APIDefinition, error) {
+	def, err := c.bundleAPIDefinition(ctx, file)
+	if err != nil {
+		return "", err
+	}
+	converted, warnings, err := c.client.ConvertAPIDefinition(def)
+	if err != nil {
+		return "", err
+	}
+	printConversionWarnings(warnings)
+	return converted, nil
+}
+
+// bundleAPIDefinition renders the API definition file with the template flags and bundles it.
+func (c *CLI) bundleAPIDefinition(ctx *cli.Context, file string) (wso2am.APIDefinition, error) {
+	t, err := c.template(ctx)
+	if err != nil {
+		return "", err
+	}
+	return t.BundleAPIDefinition(file)
+}
+
+func (c *CLI) inspectAPIDefinition(def wso2am.APIDefinition) error {
+	var v interface{}
+	if err := json.Unmarshal([]byte(def), &v); err != nil {
+		return err
+	}
+	return c.inspect(v)
+}
+
+func printConversionWarnings(warnings []wso2am.ConversionWarning) {
+	for _, w := range warnings {
+		fmt.Fprintf(os.Stderr, "warning: %s\n", w)
+	}
+}
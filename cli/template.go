@@ -0,0 +1,54 @@
+package cli
+
+import (
+	wso2am "github.com/uphy/go-wso2am"
+	"github.com/urfave/cli"
+)
+
+// templateFlags are the flags to render the API definitions and the endpoint URLs as Go templates.
+var templateFlags = []cli.Flag{
+	cli.StringSliceFlag{
+		Name:  "set",
+		Usage: "Set the template value (KEY=VALUE)",
+	},
+	cli.StringSliceFlag{
+		Name:  "values",
+		Usage: "YAML file of the template values",
+	},
+	cli.BoolFlag{
+		Name:  "strict",
+		Usage: "Fail if the template refers to an undefined value or environment variable",
+	},
+}
+
+// template creates the template from the flags.
+// It returns nil if none of the template flags is set, so the files are used as is.
+func (c *CLI) template(ctx *cli.Context) (*wso2am.Template, error) {
+	if !ctx.IsSet("set") && !ctx.IsSet("values") && !ctx.IsSet("strict") {
+		return nil, nil
+	}
+	t := wso2am.NewTemplate(ctx.Bool("strict"))
+	for _, file := range ctx.StringSlice("values") {
+		if err := t.LoadValuesFile(file); err != nil {
+			return nil, err
+		}
+	}
+	for _, expr := range ctx.StringSlice("set") {
+		if err := t.SetString(expr); err != nil {
+			return nil, err
+		}
+	}
+	return t, nil
+}
+
+// render renders the flag value as the template.
+func (c *CLI) render(ctx *cli.Context, name string) (string, error) {
+	t, err := c.template(ctx)
+	if err != nil {
+		return "", err
+	}
+	if t == nil {
+		return ctx.String(name), nil
+	}
+	return t.Render(name, ctx.String(name))
+}
@@ -0,0 +1,61 @@
+package cli
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	wso2am "github.com/uphy/go-wso2am"
+	"github.com/urfave/cli"
+)
+
+var thumbnailDownloadFlags = []cli.Flag{
+	cli.StringFlag{
+		Name:  "output,o",
+		Usage: "Output file (the extension is added from the media type if omitted; default: stdout)",
+	},
+}
+
+var thumbnailUploadFlags = []cli.Flag{
+	cli.BoolFlag{
+		Name:  "resize",
+		Usage: fmt.Sprintf("Shrink the image to fit in %dx%d", wso2am.ThumbnailPreferredSize, wso2am.ThumbnailPreferredSize),
+	},
+}
+
+// downloadThumbnail writes the thumbnail to the output file or stdout.
+func (c *CLI) downloadThumbnail(ctx *cli.Context, download func(w io.Writer) (string, error)) error {
+	output := ctx.String("output")
+	if output == "" {
+		_, err := download(os.Stdout)
+		return err
+	}
+	buf := new(bytes.Buffer)
+	mediaType, err := download(buf)
+	if err != nil {
+		return err
+	}
+	if filepath.Ext(output) == "" {
+		output += wso2am.ThumbnailExtension(mediaType)
+	}
+	if err := ioutil.WriteFile(output, buf.Bytes(), 0644); err != nil {
+		return err
+	}
+	fmt.Println(output)
+	return nil
+}
+
+// readThumbnail reads the image file and resizes it if "--resize" is specified.
+func (c *CLI) readThumbnail(ctx *cli.Context, file string) (*wso2am.ThumbnailImage, error) {
+	img, err := wso2am.ReadThumbnailFile(file)
+	if err != nil {
+		return nil, err
+	}
+	if ctx.Bool("resize") {
+		return img.Resize(wso2am.ThumbnailPreferredSize)
+	}
+	return img, nil
+}
@@ -0,0 +1,105 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	wso2am "github.com/uphy/go-wso2am"
+	"github.com/urfave/cli"
+)
+
+func (c *CLI) tier() cli.Command {
+	return cli.Command{
+		Name:  "tier",
+		Usage: "Throttling tier command",
+		Subcommands: cli.Commands{
+			c.tierList(),
+		},
+	}
+}
+
+func (c *CLI) tierList() cli.Command {
+	levels := []string{}
+	for _, l := range wso2am.TierLevels {
+		levels = append(levels, string(l))
+	}
+	return cli.Command{
+		Name:    "list",
+		Aliases: []string{"ls", "dir"},
+		Usage:   "List the throttling tiers",
+		Flags: []cli.Flag{
+			cli.StringFlag{
+				Name:  "level",
+				Usage: fmt.Sprintf("Tier level (%s)", strings.Join(levels, ", ")),
+				Value: string(wso2am.TierLevelAPI),
+			},
+			formatFlag,
+		},
+		Action: func(ctx *cli.Context) error {
+			format, err := outputFormat(ctx)
+			if err != nil {
+				return err
+			}
+			level, err := wso2am.ParseTierLevel(ctx.String("level"))
+			if err != nil {
+				return err
+			}
+			tiers, err := c.client.Tiers(level)
+			if err != nil {
+				return err
+			}
+			if format == formatJSON {
+				return c.inspect(tiers)
+			}
+			f := newTableFormatter()
+			f.Header("Name", "Requests", "UnitTime", "TierPlan", "StopOnQuotaReach", "Description")
+			for _, t := range tiers {
+				f.Row(t.Name, t.RequestCount, fmt.Sprintf("%d %s", t.UnitTime, t.TimeUnit), t.TierPlan, t.StopOnQuotaReach, t.Description)
+			}
+			f.Flush()
+			return nil
+		},
+	}
+}
+
+func (c *CLI) env() cli.Command {
+	return cli.Command{
+		Name:    "env",
+		Aliases: []string{"environment"},
+		Usage:   "Gateway environment command",
+		Subcommands: cli.Commands{
+			c.envList(),
+		},
+	}
+}
+
+func (c *CLI) envList() cli.Command {
+	return cli.Command{
+		Name:    "list",
+		Aliases: []string{"ls", "dir"},
+		Usage:   "List the gateway environments",
+		Flags: []cli.Flag{
+			formatFlag,
+		},
+		Action: func(ctx *cli.Context) error {
+			format, err := outputFormat(ctx)
+			if err != nil {
+				return err
+			}
+			envs, err := c.client.Environments()
+			if err != nil {
+				return err
+			}
+			if format == formatJSON {
+				return c.inspect(envs)
+			}
+			f := newTableFormatter()
+			f.Header("Name", "Type", "HTTP", "HTTPS")
+			for _, e := range envs {
+				f.Row(e.Name, e.Type, e.Endpoints.HTTP, e.Endpoints.HTTPS)
+			}
+			f.Flush()
+			return nil
+		},
+	}
+}
@@ -0,0 +1,166 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	multierror "github.com/hashicorp/go-multierror"
+	wso2am "github.com/uphy/go-wso2am"
+	"github.com/urfave/cli"
+)
+
+func (c *CLI) workflow() cli.Command {
+	return cli.Command{
+		Name:  "workflow",
+		Usage: "Workflow approval command",
+		Subcommands: cli.Commands{
+			c.workflowList(),
+			c.workflowUpdate(true),
+			c.workflowUpdate(false),
+		},
+	}
+}
+
+// workflowFilterFlags select the pending workflows.
+var workflowFilterFlags = []cli.Flag{
+	cli.StringFlag{
+		Name:  "type",
+		Usage: fmt.Sprintf("Select the workflows of the type (%s)", strings.Join(wso2am.WorkflowTypeNames(), ", ")),
+	},
+	cli.StringSliceFlag{
+		Name:  "where",
+		Usage: "Select the workflows which have the property KEY=VALUE (e.g. applicationName=orders, subscriber=alice)",
+	},
+}
+
+func workflowFilter(ctx *cli.Context) (*wso2am.WorkflowFilter, error) {
+	filter := &wso2am.WorkflowFilter{}
+	if ctx.IsSet("type") {
+		t, err := wso2am.ParseWorkflowType(ctx.String("type"))
+		if err != nil {
+			return nil, err
+		}
+		filter.Type = t
+	}
+	for _, expr := range ctx.StringSlice("where") {
+		if err := filter.SetProperty(expr); err != nil {
+			return nil, err
+		}
+	}
+	return filter, nil
+}
+
+func (c *CLI) workflowList() cli.Command {
+	return cli.Command{
+		Name:    "list",
+		Aliases: []string{"ls", "dir"},
+		Usage:   "List the pending workflows",
+		Flags:   append([]cli.Flag{formatFlag}, workflowFilterFlags...),
+		Action: func(ctx *cli.Context) error {
+			format, err := outputFormat(ctx)
+			if err != nil {
+				return err
+			}
+			filter, err := workflowFilter(ctx)
+			if err != nil {
+				return err
+			}
+			workflows, err := c.client.FindPendingWorkflows(filter)
+			if err != nil {
+				return err
+			}
+			if format == formatJSON {
+				return c.inspect(workflows)
+			}
+			f := newTableFormatter()
+			f.Header("ReferenceID", "Type", "Created", "Properties", "Description")
+			for _, w := range workflows {
+				f.Row(w.ReferenceID, w.Type, w.CreatedTime, w.PropertiesString(), w.Description)
+			}
+			f.Flush()
+			return nil
+		},
+	}
+}
+
+func (c *CLI) workflowUpdate(approve bool) cli.Command {
+	name, verb := "approve", "Approve"
+	if !approve {
+		name, verb = "reject", "Reject"
+	}
+	return cli.Command{
+		Name:      name,
+		Usage:     verb + " the pending workflows",
+		ArgsUsage: "[REFERENCE_ID...]",
+		Description: verb + ` the workflows of the reference IDs, or all the pending workflows selected by the filters (--type or --where) with --all, e.g.
+
+  wso2am-cli workflow ` + name + ` --all --type subscription --where subscriber=alice`,
+		Flags: append([]cli.Flag{
+			cli.StringFlag{
+				Name:  "description,d",
+				Usage: "Description shown to the requester",
+			},
+			cli.BoolFlag{
+				Name:  "all",
+				Usage: "Select all the pending workflows matching the filters (--type or --where is required)",
+			},
+			cli.BoolFlag{
+				Name:  "dry-run",
+				Usage: "Show the workflows without updating them",
+			},
+		}, workflowFilterFlags...),
+		Action: func(ctx *cli.Context) error {
+			update := c.client.ApproveWorkflow
+			if !approve {
+				update = c.client.RejectWorkflow
+			}
+			filter, err := workflowFilter(ctx)
+			if err != nil {
+				return err
+			}
+			var ids []string
+			switch {
+			case ctx.NArg() > 0 && ctx.Bool("all"):
+				return errors.New("REFERENCE_ID cannot be used with --all")
+			case ctx.NArg() > 0:
+				if filter.Type != "" || len(filter.Properties) > 0 {
+					return errors.New("REFERENCE_ID cannot be used with --type and --where")
+				}
+				ids = ctx.Args()
+				if ctx.Bool("dry-run") {
+					fmt.Println(strings.Join(ids, "\n"))
+				}
+			case ctx.Bool("all"):
+				if filter.Type == "" && len(filter.Properties) == 0 {
+					return errors.New("--all requires --type or --where")
+				}
+				workflows, err := c.client.FindPendingWorkflows(filter)
+				if err != nil {
+					return err
+				}
+				for _, w := range workflows {
+					ids = append(ids, w.ReferenceID)
+					if ctx.Bool("dry-run") {
+						fmt.Printf("%s\t%s\t%s\n", w.ReferenceID, w.Type, w.PropertiesString())
+					}
+				}
+			default:
+				return errors.New("REFERENCE_ID or --all is required")
+			}
+			if ctx.Bool("dry-run") {
+				return nil
+			}
+			var errs error
+			for _, id := range ids {
+				if err := update(id, ctx.String("description")); err != nil {
+					errs = multierror.Append(errs, err)
+					fmt.Println(err)
+				} else {
+					fmt.Println(id)
+				}
+			}
+			return errs
+		},
+	}
+}
@@ -103,6 +103,14 @@ func (c *Client) put(path string, scope string, body requestBody, v interface{})
 	return c.do(req, body, v)
 }
 
+func (c *Client) patch(path string, scope string, body requestBody, v interface{}) error {
+	req, _ := http.NewRequest("PATCH", c.endpointCarbon(path), nil)
+	if err := c.auth(scope, req); err != nil {
+		return err
+	}
+	return c.do(req, body, v)
+}
+
 func (c *Client) delete(path string, scope string, v interface{}) error {
 	req, _ := http.NewRequest("DELETE", c.endpointCarbon(path), nil)
 	if err := c.auth(scope, req); err != nil {
@@ -131,6 +139,9 @@ func (c *Client) do(req *http.Request, body requestBody, v interface{}) error {
 	}
 	var b []byte
 	if writer, ok := v.(io.Writer); ok {
+		if w, ok := writer.(*mediaTypeWriter); ok {
+			w.mediaType = resp.Header.Get("Content-Type")
+		}
 		if _, err := io.Copy(writer, resp.Body); err != nil {
 			return c.apiError(req, resp, errors.New("failed to read the response body"))
 		}
@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"regexp"
+	"strings"
 )
 
 type (
@@ -26,6 +28,28 @@ type (
 	SearchFunc func(entryc chan<- interface{}, errc chan<- error, done <-chan struct{})
 )
 
+// UUIDPattern matches the IDs of the APIs, the applications and the subscriptions.
+var UUIDPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// findNameVersion returns the index of the entry which has the exact name and the version, or -1 if not found.
+// If the version is empty, the name must identify a single entry.
+func findNameVersion(name, version string, n int, nameVersion func(i int) (string, string)) (int, error) {
+	found := -1
+	versions := []string{}
+	for i := 0; i < n; i++ {
+		entryName, entryVersion := nameVersion(i)
+		if entryName != name || version != "" && entryVersion != version {
+			continue
+		}
+		found = i
+		versions = append(versions, entryVersion)
+	}
+	if len(versions) > 1 {
+		return -1, fmt.Errorf("API %s has multiple versions (%s); specify the version", name, strings.Join(versions, ", "))
+	}
+	return found, nil
+}
+
 func (c *Client) RegisterClient(clientInfo *ClientInfo) (clientID string, clientSecret string, err error) {
 	req, err := http.NewRequest("POST", c.endpointCarbon(fmt.Sprintf("client-registration/%s/register", c.config.APIVersion)), nil)
 	req.Header.Add("Content-Type", "application/json")
@@ -97,15 +121,18 @@ func (c *Client) search(entryc chan<- interface{}, errc chan<- error, done <-cha
 	}
 	for {
 		resp, err := searchFunc(q)
-		if resp.Count == 0 {
-			return
-		}
 		if err != nil {
 			select {
 			case errc <- err:
 			case <-done:
 				return
 			}
+			// wait for the consumer to stop the search
+			<-done
+			return
+		}
+		if resp.Count == 0 {
+			return
 		}
 		for _, a := range resp.List {
 			select {
@@ -134,3 +161,13 @@ func convert(from interface{}, to interface{}) error {
 func (c *Client) publisherURL(path string) string {
 	return fmt.Sprintf("api/am/publisher/%s/%s", c.config.APIVersion, path)
 }
+
+// adminURL returns the URL of the admin API.  The admin API has the same version as the publisher API.
+func (c *Client) adminURL(path string) string {
+	return fmt.Sprintf("api/am/admin/%s/%s", c.config.APIVersion, path)
+}
+
+// storeURL returns the URL of the store (developer portal) API.  The store API has the same version as the publisher API.
+func (c *Client) storeURL(path string) string {
+	return fmt.Sprintf("api/am/store/%s/%s", c.config.APIVersion, path)
+}
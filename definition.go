@@ -0,0 +1,181 @@
+package wso2am
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+type (
+	// APIDefinitionSpec is the specification which an API definition conforms to.
+	APIDefinitionSpec string
+)
+
+const (
+	APIDefinitionSpecUnknown  APIDefinitionSpec = ""
+	APIDefinitionSpecSwagger2 APIDefinitionSpec = "swagger2"
+	APIDefinitionSpecOpenAPI3 APIDefinitionSpec = "openapi3"
+)
+
+// Spec detects the specification of the API definition.
+func (d APIDefinition) Spec() (APIDefinitionSpec, error) {
+	doc, err := d.parse()
+	if err != nil {
+		return APIDefinitionSpecUnknown, err
+	}
+	return detectAPIDefinitionSpec(doc)
+}
+
+func (d APIDefinition) parse() (map[string]interface{}, error) {
+	if d == "" {
+		return nil, errors.New("empty API definition")
+	}
+	var doc map[string]interface{}
+	if err := json.Unmarshal([]byte(d), &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse the API definition: %v", err)
+	}
+	return doc, nil
+}
+
+func newAPIDefinition(doc map[string]interface{}) (APIDefinition, error) {
+	b, err := json.Marshal(doc)
+	if err != nil {
+		return "", err
+	}
+	return APIDefinition(b), nil
+}
+
+func detectAPIDefinitionSpec(doc map[string]interface{}) (APIDefinitionSpec, error) {
+	if v, ok := doc["swagger"]; ok {
+		if fmt.Sprint(v) == "2.0" {
+			return APIDefinitionSpecSwagger2, nil
+		}
+		return APIDefinitionSpecUnknown, fmt.Errorf("unsupported swagger version: %v", v)
+	}
+	if v, ok := doc["openapi"]; ok {
+		if strings.HasPrefix(fmt.Sprint(v), "3.") {
+			return APIDefinitionSpecOpenAPI3, nil
+		}
+		return APIDefinitionSpecUnknown, fmt.Errorf("unsupported openapi version: %v", v)
+	}
+	return APIDefinitionSpecUnknown, errors.New(`the API definition has neither "swagger" nor "openapi" field`)
+}
+
+// normalizeYAML converts the maps decoded by yaml to map[string]interface{} so that they can be encoded to JSON.
+func normalizeYAML(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[interface{}]interface{}:
+		m := map[string]interface{}{}
+		for key, value := range v {
+			m[fmt.Sprint(key)] = normalizeYAML(value)
+		}
+		return m
+	case map[string]interface{}:
+		m := map[string]interface{}{}
+		for key, value := range v {
+			m[key] = normalizeYAML(value)
+		}
+		return m
+	case []interface{}:
+		l := make([]interface{}, len(v))
+		for i, value := range v {
+			l[i] = normalizeYAML(value)
+		}
+		return l
+	default:
+		return v
+	}
+}
+
+// resolveJSONPointer resolves the local reference like "#/definitions/Pet" in the document.
+func resolveJSONPointer(doc interface{}, ref string) (interface{}, bool) {
+	if !strings.HasPrefix(ref, "#") {
+		return nil, false
+	}
+	pointer := strings.TrimPrefix(ref, "#")
+	if pointer == "" {
+		return doc, true
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, false
+	}
+	current := doc
+	for _, token := range strings.Split(pointer[1:], "/") {
+		token = unescapeJSONPointer(token)
+		switch v := current.(type) {
+		case map[string]interface{}:
+			next, ok := v[token]
+			if !ok {
+				return nil, false
+			}
+			current = next
+		case []interface{}:
+			var i int
+			if _, err := fmt.Sscanf(token, "%d", &i); err != nil || i < 0 || i >= len(v) {
+				return nil, false
+			}
+			current = v[i]
+		default:
+			return nil, false
+		}
+	}
+	return current, true
+}
+
+func escapeJSONPointer(s string) string {
+	return strings.Replace(strings.Replace(s, "~", "~0", -1), "/", "~1", -1)
+}
+
+func unescapeJSONPointer(s string) string {
+	if u, err := url.PathUnescape(s); err == nil {
+		s = u
+	}
+	return strings.Replace(strings.Replace(s, "~1", "/", -1), "~0", "~", -1)
+}
+
+func asMap(v interface{}) map[string]interface{} {
+	if m, ok := v.(map[string]interface{}); ok {
+		return m
+	}
+	return map[string]interface{}{}
+}
+
+func asSlice(v interface{}) []interface{} {
+	if l, ok := v.([]interface{}); ok {
+		return l
+	}
+	return []interface{}{}
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func containsString(l []string, s string) bool {
+	for _, v := range l {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// containsValue reports whether l contains v.  The values are compared deeply,
+// so that the maps and the slices decoded from JSON can be compared.
+func containsValue(l []interface{}, v interface{}) bool {
+	for _, e := range l {
+		if reflect.DeepEqual(e, v) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,318 @@
+package wso2am
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	yaml "gopkg.in/yaml.v2"
+)
+
+type (
+	definitionBundler struct {
+		// template renders the files before parsing them if not nil.
+		template *Template
+		rootFile string
+		spec     APIDefinitionSpec
+		// docs caches the loaded documents by the absolute file path.
+		docs map[string]interface{}
+		// schemas is the hoisted schemas by the name.
+		schemas map[string]interface{}
+		// schemaNames is the name of the hoisted schemas by the reference key(FILE#POINTER).
+		schemaNames map[string]string
+		// reserved is the set of the schema names already used.
+		reserved map[string]bool
+		// inlining is the stack of the reference keys being inlined for the cycle detection.
+		inlining []string
+	}
+	bundleMode int
+)
+
+const (
+	bundleModeNone bundleMode = iota
+	bundleModeSchema
+	// bundleModeSchemaMap is the map whose values are schemas. (e.g. properties)
+	bundleModeSchemaMap
+	// bundleModeSchemaList is the list whose elements are schemas. (e.g. allOf)
+	bundleModeSchemaList
+	// bundleModeLiteral is the value which must not be resolved. (e.g. example)
+	bundleModeLiteral
+)
+
+var schemaNamePattern = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// BundleAPIDefinition loads the API definition file and resolves the external references.
+//
+// The references to the other files (e.g. "common.yaml#/definitions/Error") are resolved relative to
+// the referring file.  The referenced schemas are hoisted into "definitions" ("components/schemas" for OpenAPI 3)
+// and the other referenced objects are inlined.  URL references are not supported.
+func BundleAPIDefinition(path string) (APIDefinition, error) {
+	return bundleAPIDefinition(path, nil)
+}
+
+func bundleAPIDefinition(path string, t *Template) (APIDefinition, error) {
+	rootFile, err := filepath.Abs(path)
+	if err != nil {
+		return "", err
+	}
+	b := &definitionBundler{
+		template:    t,
+		rootFile:    rootFile,
+		docs:        map[string]interface{}{},
+		schemas:     map[string]interface{}{},
+		schemaNames: map[string]string{},
+		reserved:    map[string]bool{},
+	}
+	doc, err := b.load(rootFile)
+	if err != nil {
+		return "", err
+	}
+	root, ok := doc.(map[string]interface{})
+	if !ok {
+		return "", fmt.Errorf("the API definition is not an object: %s", path)
+	}
+	if b.spec, err = detectAPIDefinitionSpec(root); err != nil {
+		return "", err
+	}
+	schemas := asMap(root["definitions"])
+	if b.spec == APIDefinitionSpecOpenAPI3 {
+		schemas = asMap(asMap(root["components"])["schemas"])
+	}
+	for name := range schemas {
+		b.reserved[name] = true
+	}
+
+	resolved, err := b.walk(root, rootFile, bundleModeNone, nil)
+	if err != nil {
+		return "", err
+	}
+	bundled := resolved.(map[string]interface{})
+	if len(b.schemas) > 0 {
+		schemas := b.rootSchemas(bundled)
+		for name, schema := range b.schemas {
+			schemas[name] = schema
+		}
+	}
+	return newAPIDefinition(bundled)
+}
+
+// rootSchemas returns the schema definitions of the document creating it if not exist.
+func (b *definitionBundler) rootSchemas(root map[string]interface{}) map[string]interface{} {
+	parent := root
+	key := "definitions"
+	if b.spec == APIDefinitionSpecOpenAPI3 {
+		if _, ok := root["components"].(map[string]interface{}); !ok {
+			root["components"] = map[string]interface{}{}
+		}
+		parent = root["components"].(map[string]interface{})
+		key = "schemas"
+	}
+	if _, ok := parent[key].(map[string]interface{}); !ok {
+		parent[key] = map[string]interface{}{}
+	}
+	return parent[key].(map[string]interface{})
+}
+
+func (b *definitionBundler) schemaRef(name string) string {
+	if b.spec == APIDefinitionSpecOpenAPI3 {
+		return "#/components/schemas/" + escapeJSONPointer(name)
+	}
+	return "#/definitions/" + escapeJSONPointer(name)
+}
+
+func (b *definitionBundler) load(file string) (interface{}, error) {
+	if doc, ok := b.docs[file]; ok {
+		return doc, nil
+	}
+	ext := strings.ToLower(filepath.Ext(file))
+	if ext != ".json" && ext != ".yaml" && ext != ".yml" {
+		return nil, fmt.Errorf("unsupported swagger file format: %s", file)
+	}
+	data, err := b.template.renderFile(file)
+	if err != nil {
+		return nil, err
+	}
+	var doc interface{}
+	if ext == ".json" {
+		err = json.Unmarshal(data, &doc)
+	} else {
+		err = yaml.Unmarshal(data, &doc)
+		doc = normalizeYAML(doc)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %v", file, err)
+	}
+	b.docs[file] = doc
+	return doc, nil
+}
+
+func (b *definitionBundler) walk(v interface{}, file string, mode bundleMode, path []string) (interface{}, error) {
+	switch mode {
+	case bundleModeLiteral:
+		return v, nil
+	case bundleModeSchemaMap:
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			return v, nil
+		}
+		out := map[string]interface{}{}
+		for _, k := range sortedKeys(m) {
+			resolved, err := b.walk(m[k], file, bundleModeSchema, appendPath(path, k))
+			if err != nil {
+				return nil, err
+			}
+			out[k] = resolved
+		}
+		return out, nil
+	case bundleModeSchemaList:
+		mode = bundleModeSchema
+	}
+
+	switch v := v.(type) {
+	case map[string]interface{}:
+		if ref, ok := v["$ref"].(string); ok {
+			return b.resolveRef(ref, file, mode, path)
+		}
+		out := map[string]interface{}{}
+		for _, k := range sortedKeys(v) {
+			resolved, err := b.walk(v[k], file, b.childMode(mode, path, k), appendPath(path, k))
+			if err != nil {
+				return nil, err
+			}
+			out[k] = resolved
+		}
+		return out, nil
+	case []interface{}:
+		out := make([]interface{}, len(v))
+		for i, e := range v {
+			resolved, err := b.walk(e, file, mode, appendPath(path, fmt.Sprint(i)))
+			if err != nil {
+				return nil, err
+			}
+			out[i] = resolved
+		}
+		return out, nil
+	default:
+		return v, nil
+	}
+}
+
+func (b *definitionBundler) childMode(mode bundleMode, path []string, key string) bundleMode {
+	switch key {
+	case "example", "examples", "enum", "x-examples", "x-example":
+		return bundleModeLiteral
+	case "default":
+		// "default" of the responses is the response object, not the default value.
+		if len(path) == 0 || path[len(path)-1] != "responses" {
+			return bundleModeLiteral
+		}
+	}
+	if mode == bundleModeSchema {
+		switch key {
+		case "properties", "patternProperties", "definitions":
+			return bundleModeSchemaMap
+		case "items", "additionalProperties", "not":
+			return bundleModeSchema
+		case "allOf", "oneOf", "anyOf":
+			return bundleModeSchemaList
+		}
+		return bundleModeNone
+	}
+	switch {
+	case key == "schema":
+		return bundleModeSchema
+	case len(path) == 0 && key == "definitions":
+		return bundleModeSchemaMap
+	case len(path) == 1 && path[0] == "components" && key == "schemas":
+		return bundleModeSchemaMap
+	}
+	return bundleModeNone
+}
+
+func (b *definitionBundler) resolveRef(ref string, file string, mode bundleMode, path []string) (interface{}, error) {
+	u, err := url.Parse(ref)
+	if err != nil {
+		return nil, fmt.Errorf("invalid reference %s in %s: %v", ref, file, err)
+	}
+	if u.Scheme != "" || u.Host != "" {
+		return nil, fmt.Errorf("URL references are not supported: %s in %s", ref, file)
+	}
+	targetFile := file
+	if u.Path != "" {
+		targetFile = filepath.Join(filepath.Dir(file), filepath.FromSlash(u.Path))
+	}
+	pointer := "#" + u.Fragment
+	if targetFile == b.rootFile {
+		return map[string]interface{}{"$ref": pointer}, nil
+	}
+
+	key := targetFile + pointer
+	if mode == bundleModeSchema {
+		if name, ok := b.schemaNames[key]; ok {
+			return map[string]interface{}{"$ref": b.schemaRef(name)}, nil
+		}
+		target, err := b.target(targetFile, pointer, ref, file)
+		if err != nil {
+			return nil, err
+		}
+		name := b.schemaName(targetFile, pointer)
+		// register the name before resolving the schema so that the recursive schemas refer to the name.
+		b.schemaNames[key] = name
+		resolved, err := b.walk(target, targetFile, bundleModeSchema, nil)
+		if err != nil {
+			return nil, err
+		}
+		b.schemas[name] = resolved
+		return map[string]interface{}{"$ref": b.schemaRef(name)}, nil
+	}
+
+	for i, k := range b.inlining {
+		if k == key {
+			return nil, fmt.Errorf("circular reference: %s", strings.Join(append(b.inlining[i:], key), " -> "))
+		}
+	}
+	target, err := b.target(targetFile, pointer, ref, file)
+	if err != nil {
+		return nil, err
+	}
+	b.inlining = append(b.inlining, key)
+	resolved, err := b.walk(target, targetFile, mode, path)
+	b.inlining = b.inlining[:len(b.inlining)-1]
+	return resolved, err
+}
+
+func (b *definitionBundler) target(targetFile, pointer, ref, file string) (interface{}, error) {
+	doc, err := b.load(targetFile)
+	if err != nil {
+		return nil, err
+	}
+	target, ok := resolveJSONPointer(doc, pointer)
+	if !ok {
+		return nil, fmt.Errorf("cannot resolve the reference %s in %s", ref, file)
+	}
+	return target, nil
+}
+
+// schemaName decides the name of the hoisted schema from the last token of the pointer or the file name.
+func (b *definitionBundler) schemaName(file, pointer string) string {
+	var name string
+	if i := strings.LastIndex(pointer, "/"); i >= 0 && i < len(pointer)-1 {
+		name = unescapeJSONPointer(pointer[i+1:])
+	} else {
+		name = strings.TrimSuffix(filepath.Base(file), filepath.Ext(file))
+	}
+	name = schemaNamePattern.ReplaceAllString(name, "_")
+	candidate := name
+	for i := 2; b.reserved[candidate]; i++ {
+		candidate = fmt.Sprintf("%s_%d", name, i)
+	}
+	b.reserved[candidate] = true
+	return candidate
+}
+
+func appendPath(path []string, key string) []string {
+	return append(append(make([]string, 0, len(path)+1), path...), key)
+}
@@ -0,0 +1,144 @@
+package wso2am
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestBundleAPIDefinition(t *testing.T) {
+	const root = `swagger: "2.0"
+info: {title: pets, version: 1.0.0}
+paths:
+  /pets:
+    get:
+      responses:
+        "200": {$ref: "responses.yaml#/ok"}
+`
+	tests := []struct {
+		name    string
+		files   map[string]string
+		want    string
+		wantErr string
+	}{
+		{
+			name: "schemas are hoisted and the other objects are inlined",
+			files: map[string]string{
+				"root.yaml": root,
+				"responses.yaml": `ok:
+  description: OK
+  schema: {$ref: "schemas/pet.json#/Pet"}
+`,
+				"schemas/pet.json": `{
+  "Pet": {"type": "object", "properties": {"owner": {"$ref": "#/Owner"}, "parent": {"$ref": "#/Pet"}}},
+  "Owner": {"type": "object", "properties": {"name": {"type": "string"}}}
+}`,
+			},
+			want: `{
+  "swagger": "2.0",
+  "info": {"title": "pets", "version": "1.0.0"},
+  "paths": {
+    "/pets": {
+      "get": {
+        "responses": {"200": {"description": "OK", "schema": {"$ref": "#/definitions/Pet"}}}
+      }
+    }
+  },
+  "definitions": {
+    "Pet": {"type": "object", "properties": {"owner": {"$ref": "#/definitions/Owner"}, "parent": {"$ref": "#/definitions/Pet"}}},
+    "Owner": {"type": "object", "properties": {"name": {"type": "string"}}}
+  }
+}`,
+		},
+		{
+			name: "hoisted schema is renamed if the name is already used",
+			files: map[string]string{
+				"root.yaml": root + `definitions:
+  Pet: {type: string}
+`,
+				"responses.yaml": `ok:
+  description: OK
+  schema: {$ref: "pet.yaml#/Pet"}
+`,
+				"pet.yaml": `Pet: {type: object}
+`,
+			},
+			want: `{
+  "swagger": "2.0",
+  "info": {"title": "pets", "version": "1.0.0"},
+  "paths": {
+    "/pets": {
+      "get": {
+        "responses": {"200": {"description": "OK", "schema": {"$ref": "#/definitions/Pet_2"}}}
+      }
+    }
+  },
+  "definitions": {
+    "Pet": {"type": "string"},
+    "Pet_2": {"type": "object"}
+  }
+}`,
+		},
+		{
+			name: "circular reference of the inlined objects",
+			files: map[string]string{
+				"root.yaml": root,
+				"responses.yaml": `ok: {$ref: "#/alias"}
+alias: {$ref: "#/ok"}
+`,
+			},
+			wantErr: "circular reference: ",
+		},
+		{
+			name: "unsupported file format",
+			files: map[string]string{
+				"root.yaml": root,
+				"responses.yaml": `ok:
+  description: OK
+  schema: {$ref: "pet.txt#/Pet"}
+`,
+				"pet.txt": `Pet: {type: object}
+`,
+			},
+			wantErr: "unsupported swagger file format: ",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			for name, content := range tt.files {
+				file := filepath.Join(dir, filepath.FromSlash(name))
+				if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+					t.Fatal(err)
+				}
+				if err := ioutil.WriteFile(file, []byte(content), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			def, err := BundleAPIDefinition(filepath.Join(dir, "root.yaml"))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("err = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			got, err := def.parse()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			want, err := APIDefinition(tt.want).parse()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("bundled = %s, want %s", def, tt.want)
+			}
+		})
+	}
+}
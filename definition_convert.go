@@ -0,0 +1,1227 @@
+package wso2am
+
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+	"strings"
+)
+
+type (
+	// ConversionWarning describes a construct which could not be converted without loss.
+	ConversionWarning struct {
+		// Path is the JSON pointer like location of the construct in the source definition.
+		Path    string
+		Message string
+	}
+	definitionConverter struct {
+		root     map[string]interface{}
+		warnings []ConversionWarning
+	}
+)
+
+var (
+	swagger2Operations = []string{"get", "put", "post", "delete", "options", "head", "patch"}
+	formMediaTypes     = []string{"application/x-www-form-urlencoded", "multipart/form-data"}
+	// swagger2ParameterSchemaFields are the schema fields which non-body parameters, headers and items can have in Swagger 2.0.
+	swagger2ParameterSchemaFields = []string{
+		"type", "format", "items", "collectionFormat", "default", "maximum", "exclusiveMaximum",
+		"minimum", "exclusiveMinimum", "maxLength", "minLength", "pattern", "maxItems", "minItems",
+		"uniqueItems", "enum", "multipleOf",
+	}
+	oauth2Flows = [][2]string{
+		// {OpenAPI 3, Swagger 2.0}
+		{"implicit", "implicit"},
+		{"password", "password"},
+		{"clientCredentials", "application"},
+		{"authorizationCode", "accessCode"},
+	}
+)
+
+func (w ConversionWarning) String() string {
+	return fmt.Sprintf("%s: %s", w.Path, w.Message)
+}
+
+// ToSwagger2 converts the OpenAPI 3 definition to Swagger 2.0.
+// The definition is returned as is if it is already Swagger 2.0.
+func (d APIDefinition) ToSwagger2() (APIDefinition, []ConversionWarning, error) {
+	return d.ConvertTo(APIDefinitionSpecSwagger2)
+}
+
+// ToOpenAPI3 converts the Swagger 2.0 definition to OpenAPI 3.
+// The definition is returned as is if it is already OpenAPI 3.
+func (d APIDefinition) ToOpenAPI3() (APIDefinition, []ConversionWarning, error) {
+	return d.ConvertTo(APIDefinitionSpecOpenAPI3)
+}
+
+// ConvertTo converts the definition to the specification.
+// The warnings describe the constructs which could not be converted without loss.
+func (d APIDefinition) ConvertTo(to APIDefinitionSpec) (APIDefinition, []ConversionWarning, error) {
+	doc, err := d.parse()
+	if err != nil {
+		return "", nil, err
+	}
+	from, err := detectAPIDefinitionSpec(doc)
+	if err != nil {
+		return "", nil, err
+	}
+	if from == to {
+		return d, []ConversionWarning{}, nil
+	}
+	c := &definitionConverter{root: doc, warnings: []ConversionWarning{}}
+	var converted map[string]interface{}
+	switch to {
+	case APIDefinitionSpecSwagger2:
+		converted = c.openAPI3ToSwagger2()
+	case APIDefinitionSpecOpenAPI3:
+		converted = c.swagger2ToOpenAPI3()
+	default:
+		return "", nil, fmt.Errorf("unsupported API definition spec: %s", to)
+	}
+	def, err := newAPIDefinition(converted)
+	if err != nil {
+		return "", nil, err
+	}
+	return def, c.warnings, nil
+}
+
+// PreferredAPIDefinitionSpec returns the specification which the API definitions are converted to for the server.
+// APIM 2.x (publisher API v0.x) accepts Swagger 2.0 only, and the newer versions are based on OpenAPI 3.
+func (c *Client) PreferredAPIDefinitionSpec() APIDefinitionSpec {
+	if strings.HasPrefix(c.config.APIVersion, "v0.") {
+		return APIDefinitionSpecSwagger2
+	}
+	return APIDefinitionSpecOpenAPI3
+}
+
+// AcceptsAPIDefinitionSpec returns true if the server accepts the API definitions of the specification as is.
+// APIM 2.x (publisher API v0.x) accepts Swagger 2.0 only, and the newer versions accept both.
+func (c *Client) AcceptsAPIDefinitionSpec(spec APIDefinitionSpec) bool {
+	return spec == APIDefinitionSpecSwagger2 || !strings.HasPrefix(c.config.APIVersion, "v0.")
+}
+
+// ConvertAPIDefinition converts the definition to the specification preferred by the server
+// only if the server does not accept the specification of the definition, because the conversion can lose information.
+func (c *Client) ConvertAPIDefinition(def APIDefinition) (APIDefinition, []ConversionWarning, error) {
+	spec, err := def.Spec()
+	if err != nil {
+		return "", nil, err
+	}
+	if c.AcceptsAPIDefinitionSpec(spec) {
+		return def, []ConversionWarning{}, nil
+	}
+	return def.ConvertTo(c.PreferredAPIDefinitionSpec())
+}
+
+func (c *definitionConverter) warn(path string, format string, args ...interface{}) {
+	c.warnings = append(c.warnings, ConversionWarning{Path: path, Message: fmt.Sprintf(format, args...)})
+}
+
+// OpenAPI 3 to Swagger 2.0
+
+func (c *definitionConverter) openAPI3ToSwagger2() map[string]interface{} {
+	out := map[string]interface{}{"swagger": "2.0"}
+	for _, k := range sortedKeys(c.root) {
+		v := c.root[k]
+		switch k {
+		case "openapi", "servers", "paths", "components":
+		case "info", "tags", "externalDocs", "security":
+			out[k] = v
+		default:
+			if strings.HasPrefix(k, "x-") {
+				out[k] = v
+			} else {
+				c.warn("/"+k, "unsupported field is removed")
+			}
+		}
+	}
+	c.serversToSwagger2(out)
+
+	components := asMap(c.root["components"])
+	for _, k := range sortedKeys(components) {
+		path := "/components/" + k
+		switch k {
+		case "schemas":
+			out["definitions"] = c.schemasToSwagger2(path, asMap(components[k]))
+		case "parameters":
+			parameters := map[string]interface{}{}
+			for _, name := range sortedKeys(asMap(components[k])) {
+				if p := c.parameterToSwagger2(path+"/"+name, asMap(components[k])[name]); p != nil {
+					parameters[name] = p
+				}
+			}
+			out["parameters"] = parameters
+		case "responses":
+			responses := map[string]interface{}{}
+			for _, name := range sortedKeys(asMap(components[k])) {
+				responses[name], _ = c.responseToSwagger2(path+"/"+name, asMap(components[k])[name])
+			}
+			out["responses"] = responses
+		case "securitySchemes":
+			out["securityDefinitions"] = c.securitySchemesToSwagger2(path, asMap(components[k]))
+		case "requestBodies":
+			// inlined into the operations
+		default:
+			if !strings.HasPrefix(k, "x-") {
+				c.warn(path, "components are not supported by Swagger 2.0 and removed")
+			}
+		}
+	}
+
+	paths := map[string]interface{}{}
+	for _, p := range sortedKeys(asMap(c.root["paths"])) {
+		paths[p] = c.pathItemToSwagger2("/paths/"+escapeJSONPointer(p), asMap(asMap(c.root["paths"])[p]))
+	}
+	out["paths"] = paths
+	return out
+}
+
+func (c *definitionConverter) serversToSwagger2(out map[string]interface{}) {
+	servers := asSlice(c.root["servers"])
+	if len(servers) == 0 {
+		return
+	}
+	var (
+		host     string
+		basePath string
+		schemes  = []interface{}{}
+	)
+	for i, s := range servers {
+		server := asMap(s)
+		rawURL := fmt.Sprint(server["url"])
+		variables := asMap(server["variables"])
+		for _, name := range sortedKeys(variables) {
+			rawURL = strings.Replace(rawURL, "{"+name+"}", fmt.Sprint(asMap(variables[name])["default"]), -1)
+		}
+		if len(variables) > 0 {
+			c.warn(fmt.Sprintf("/servers/%d/variables", i), "server variables are replaced by the default values")
+		}
+		u, err := url.Parse(rawURL)
+		if err != nil {
+			c.warn(fmt.Sprintf("/servers/%d/url", i), "invalid server url is removed: %v", err)
+			continue
+		}
+		if i == 0 {
+			host = u.Host
+			basePath = u.Path
+		} else if u.Host != host || u.Path != basePath {
+			c.warn(fmt.Sprintf("/servers/%d", i), "Swagger 2.0 supports only one host and base path; the server is removed")
+			continue
+		}
+		if u.Scheme != "" && !containsValue(schemes, u.Scheme) {
+			schemes = append(schemes, u.Scheme)
+		}
+	}
+	if host != "" {
+		out["host"] = host
+	}
+	if basePath != "" {
+		out["basePath"] = basePath
+	}
+	if len(schemes) > 0 {
+		out["schemes"] = schemes
+	}
+}
+
+func (c *definitionConverter) pathItemToSwagger2(path string, item map[string]interface{}) map[string]interface{} {
+	out := map[string]interface{}{}
+	for _, k := range sortedKeys(item) {
+		switch {
+		case k == "parameters":
+			out[k] = c.parametersToSwagger2(path+"/"+k, asSlice(item[k]))
+		case containsString(swagger2Operations, k):
+			out[k] = c.operationToSwagger2(path+"/"+k, asMap(item[k]))
+		case strings.HasPrefix(k, "x-"):
+			out[k] = item[k]
+		default:
+			c.warn(path+"/"+k, "unsupported field is removed")
+		}
+	}
+	return out
+}
+
+func (c *definitionConverter) operationToSwagger2(path string, op map[string]interface{}) map[string]interface{} {
+	out := map[string]interface{}{}
+	parameters := []interface{}{}
+	for _, k := range sortedKeys(op) {
+		v := op[k]
+		switch k {
+		case "tags", "summary", "description", "externalDocs", "operationId", "deprecated", "security":
+			out[k] = v
+		case "parameters":
+			parameters = append(parameters, c.parametersToSwagger2(path+"/"+k, asSlice(v))...)
+		case "requestBody":
+			params, consumes := c.requestBodyToSwagger2(path+"/"+k, asMap(v))
+			parameters = append(parameters, params...)
+			if len(consumes) > 0 {
+				out["consumes"] = consumes
+			}
+		case "responses":
+			responses := map[string]interface{}{}
+			produces := []interface{}{}
+			for _, code := range sortedKeys(asMap(v)) {
+				r, mediaTypes := c.responseToSwagger2(path+"/responses/"+code, asMap(v)[code])
+				responses[code] = r
+				for _, mediaType := range mediaTypes {
+					if !containsValue(produces, mediaType) {
+						produces = append(produces, mediaType)
+					}
+				}
+			}
+			out[k] = responses
+			if len(produces) > 0 {
+				out["produces"] = produces
+			}
+		default:
+			if strings.HasPrefix(k, "x-") {
+				out[k] = v
+			} else {
+				c.warn(path+"/"+k, "%s is not supported by Swagger 2.0 and removed", k)
+			}
+		}
+	}
+	if len(parameters) > 0 {
+		out["parameters"] = parameters
+	}
+	return out
+}
+
+func (c *definitionConverter) parametersToSwagger2(path string, parameters []interface{}) []interface{} {
+	out := []interface{}{}
+	for i, p := range parameters {
+		if converted := c.parameterToSwagger2(fmt.Sprintf("%s/%d", path, i), p); converted != nil {
+			out = append(out, converted)
+		}
+	}
+	return out
+}
+
+func (c *definitionConverter) parameterToSwagger2(path string, v interface{}) map[string]interface{} {
+	p := asMap(v)
+	if ref, ok := p["$ref"].(string); ok {
+		// the referenced cookie parameter is removed from the parameters
+		if resolved := c.resolveRef(ref); resolved != nil && resolved["in"] == "cookie" {
+			c.warn(path, "reference to the cookie parameter %s is removed", ref)
+			return nil
+		}
+		return map[string]interface{}{"$ref": c.refToSwagger2(path, ref)}
+	}
+	in := fmt.Sprint(p["in"])
+	if in == "cookie" {
+		c.warn(path, "cookie parameters are not supported by Swagger 2.0 and removed")
+		return nil
+	}
+	out := map[string]interface{}{}
+	for _, k := range sortedKeys(p) {
+		switch k {
+		case "name", "in", "description", "required", "allowEmptyValue":
+			out[k] = p[k]
+		case "schema", "style", "explode":
+		case "example":
+			out["x-example"] = p[k]
+		case "content":
+			c.warn(path+"/content", "parameter content is not supported by Swagger 2.0; the schema of the first media type is used")
+		default:
+			if strings.HasPrefix(k, "x-") {
+				out[k] = p[k]
+			} else {
+				c.warn(path+"/"+k, "unsupported field is removed")
+			}
+		}
+	}
+
+	schema := asMap(p["schema"])
+	if _, ok := p["schema"]; !ok {
+		content := asMap(p["content"])
+		if keys := sortedKeys(content); len(keys) > 0 {
+			schema = asMap(asMap(content[keys[0]])["schema"])
+		}
+	}
+	c.flattenSchemaToSwagger2(path+"/schema", schema, out)
+
+	if out["type"] == "array" {
+		style, _ := p["style"].(string)
+		if style == "" {
+			if in == "query" {
+				style = "form"
+			} else {
+				style = "simple"
+			}
+		}
+		explode, ok := p["explode"].(bool)
+		if !ok {
+			explode = style == "form"
+		}
+		switch {
+		case style == "form" && explode:
+			if in == "query" {
+				out["collectionFormat"] = "multi"
+			} else {
+				c.warn(path+"/explode", "exploded %s parameters are not supported by Swagger 2.0", in)
+			}
+		case style == "form", style == "simple":
+			out["collectionFormat"] = "csv"
+		case style == "spaceDelimited":
+			out["collectionFormat"] = "ssv"
+		case style == "pipeDelimited":
+			out["collectionFormat"] = "pipes"
+		default:
+			c.warn(path+"/style", "parameter style %s is not supported by Swagger 2.0", style)
+		}
+	} else if _, ok := p["style"]; ok {
+		c.warn(path+"/style", "parameter style of non-array parameters is not supported by Swagger 2.0 and removed")
+	}
+	return out
+}
+
+// flattenSchemaToSwagger2 copies the schema fields to the non-body parameter or header.
+func (c *definitionConverter) flattenSchemaToSwagger2(path string, schema map[string]interface{}, out map[string]interface{}) {
+	if ref, ok := schema["$ref"].(string); ok {
+		resolved := c.resolveRef(ref)
+		if resolved == nil {
+			c.warn(path, "unresolvable reference %s is removed", ref)
+			return
+		}
+		schema = resolved
+	}
+	converted := asMap(c.schemaToSwagger2(path, schema))
+	for _, k := range sortedKeys(converted) {
+		switch {
+		case containsString(swagger2ParameterSchemaFields, k):
+			if k == "items" {
+				items := map[string]interface{}{}
+				c.flattenSchemaToSwagger2(path+"/items", asMap(asMap(schema)["items"]), items)
+				out[k] = items
+			} else {
+				out[k] = converted[k]
+			}
+		case strings.HasPrefix(k, "x-"):
+			out[k] = converted[k]
+		case k == "description", k == "example", k == "title", k == "readOnly":
+		default:
+			c.warn(path+"/"+k, "%s is not supported in non-body parameters by Swagger 2.0 and removed", k)
+		}
+	}
+	if out["type"] == "object" {
+		c.warn(path, "object type is not supported in non-body parameters by Swagger 2.0; string is used")
+		out["type"] = "string"
+	}
+}
+
+func (c *definitionConverter) requestBodyToSwagger2(path string, body map[string]interface{}) (params []interface{}, consumes []interface{}) {
+	if ref, ok := body["$ref"].(string); ok {
+		resolved := c.resolveRef(ref)
+		if resolved == nil {
+			c.warn(path, "unresolvable reference %s is removed", ref)
+			return []interface{}{}, nil
+		}
+		body = resolved
+	}
+	content := asMap(body["content"])
+	mediaTypes := sortedKeys(content)
+	consumes = []interface{}{}
+	var formTypes, otherTypes []string
+	for _, mediaType := range mediaTypes {
+		consumes = append(consumes, mediaType)
+		if containsString(formMediaTypes, mediaType) {
+			formTypes = append(formTypes, mediaType)
+		} else {
+			otherTypes = append(otherTypes, mediaType)
+		}
+	}
+	required, _ := body["required"].(bool)
+
+	if len(mediaTypes) == 0 {
+		c.warn(path, "request body without content is removed")
+		return []interface{}{}, nil
+	}
+	if len(otherTypes) == 0 && len(formTypes) > 0 {
+		return c.formParametersToSwagger2(path+"/content/"+escapeJSONPointer(formTypes[0])+"/schema", asMap(asMap(content[formTypes[0]])["schema"])), consumes
+	}
+	if len(formTypes) > 0 {
+		c.warn(path+"/content", "Swagger 2.0 cannot mix form data and body; form media types %v use the body schema", formTypes)
+	}
+
+	mediaType := otherTypes[0]
+	if containsString(otherTypes, "application/json") {
+		mediaType = "application/json"
+	}
+	schema := asMap(content[mediaType])["schema"]
+	for _, t := range otherTypes {
+		if !reflect.DeepEqual(asMap(content[t])["schema"], schema) {
+			c.warn(path+"/content/"+escapeJSONPointer(t), "Swagger 2.0 supports only one body schema; the schema of %s is used", mediaType)
+		}
+	}
+	name := "body"
+	if n, ok := body["x-codegen-request-body-name"].(string); ok {
+		name = n
+	}
+	param := map[string]interface{}{
+		"name":     name,
+		"in":       "body",
+		"required": required,
+	}
+	if description, ok := body["description"]; ok {
+		param["description"] = description
+	}
+	if schema != nil {
+		param["schema"] = c.schemaToSwagger2(path+"/content/"+escapeJSONPointer(mediaType)+"/schema", schema)
+	} else {
+		param["schema"] = map[string]interface{}{}
+	}
+	return []interface{}{param}, consumes
+}
+
+func (c *definitionConverter) formParametersToSwagger2(path string, schema map[string]interface{}) []interface{} {
+	if ref, ok := schema["$ref"].(string); ok {
+		resolved := c.resolveRef(ref)
+		if resolved == nil {
+			c.warn(path, "unresolvable reference %s is removed", ref)
+			return []interface{}{}
+		}
+		schema = resolved
+	}
+	params := []interface{}{}
+	required := asSlice(schema["required"])
+	properties := asMap(schema["properties"])
+	for _, name := range sortedKeys(properties) {
+		property := asMap(properties[name])
+		param := map[string]interface{}{
+			"name":     name,
+			"in":       "formData",
+			"required": containsValue(required, name),
+		}
+		if description, ok := property["description"]; ok {
+			param["description"] = description
+		}
+		if property["type"] == "string" && (property["format"] == "binary" || property["format"] == "base64") {
+			param["type"] = "file"
+		} else {
+			c.flattenSchemaToSwagger2(path+"/properties/"+escapeJSONPointer(name), property, param)
+		}
+		params = append(params, param)
+	}
+	return params
+}
+
+func (c *definitionConverter) responseToSwagger2(path string, v interface{}) (map[string]interface{}, []string) {
+	r := asMap(v)
+	if ref, ok := r["$ref"].(string); ok {
+		mediaTypes := []string{}
+		if resolved := c.resolveRef(ref); resolved != nil {
+			mediaTypes = sortedKeys(asMap(resolved["content"]))
+		}
+		return map[string]interface{}{"$ref": c.refToSwagger2(path, ref)}, mediaTypes
+	}
+	out := map[string]interface{}{"description": ""}
+	mediaTypes := []string{}
+	for _, k := range sortedKeys(r) {
+		switch k {
+		case "description":
+			out[k] = r[k]
+		case "headers":
+			headers := map[string]interface{}{}
+			for _, name := range sortedKeys(asMap(r[k])) {
+				header := asMap(asMap(r[k])[name])
+				h := map[string]interface{}{}
+				if description, ok := header["description"]; ok {
+					h["description"] = description
+				}
+				c.flattenSchemaToSwagger2(path+"/headers/"+escapeJSONPointer(name)+"/schema", asMap(header["schema"]), h)
+				headers[name] = h
+			}
+			out[k] = headers
+		case "content":
+			content := asMap(r[k])
+			mediaTypes = sortedKeys(content)
+			if len(mediaTypes) == 0 {
+				continue
+			}
+			mediaType := mediaTypes[0]
+			if containsString(mediaTypes, "application/json") {
+				mediaType = "application/json"
+			}
+			if schema, ok := asMap(content[mediaType])["schema"]; ok {
+				out["schema"] = c.schemaToSwagger2(path+"/content/"+escapeJSONPointer(mediaType)+"/schema", schema)
+			}
+			examples := map[string]interface{}{}
+			for _, t := range mediaTypes {
+				if !reflect.DeepEqual(asMap(content[t])["schema"], asMap(content[mediaType])["schema"]) {
+					c.warn(path+"/content/"+escapeJSONPointer(t), "Swagger 2.0 supports only one response schema; the schema of %s is used", mediaType)
+				}
+				if example, ok := asMap(content[t])["example"]; ok {
+					examples[t] = example
+				}
+			}
+			if len(examples) > 0 {
+				out["examples"] = examples
+			}
+		default:
+			if strings.HasPrefix(k, "x-") {
+				out[k] = r[k]
+			} else {
+				c.warn(path+"/"+k, "%s is not supported by Swagger 2.0 and removed", k)
+			}
+		}
+	}
+	return out, mediaTypes
+}
+
+func (c *definitionConverter) schemasToSwagger2(path string, schemas map[string]interface{}) map[string]interface{} {
+	out := map[string]interface{}{}
+	for _, name := range sortedKeys(schemas) {
+		out[name] = c.schemaToSwagger2(path+"/"+escapeJSONPointer(name), schemas[name])
+	}
+	return out
+}
+
+func (c *definitionConverter) schemaToSwagger2(path string, v interface{}) interface{} {
+	schema, ok := v.(map[string]interface{})
+	if !ok {
+		return v
+	}
+	out := map[string]interface{}{}
+	for _, k := range sortedKeys(schema) {
+		value := schema[k]
+		switch k {
+		case "$ref":
+			out[k] = c.refToSwagger2(path, fmt.Sprint(value))
+		case "nullable":
+			out["x-nullable"] = value
+		case "deprecated":
+			out["x-deprecated"] = value
+		case "oneOf", "anyOf", "not", "writeOnly":
+			c.warn(path+"/"+k, "%s is not supported by Swagger 2.0 and removed", k)
+		case "properties":
+			properties := map[string]interface{}{}
+			for _, name := range sortedKeys(asMap(value)) {
+				properties[name] = c.schemaToSwagger2(path+"/properties/"+escapeJSONPointer(name), asMap(value)[name])
+			}
+			out[k] = properties
+		case "items", "additionalProperties":
+			out[k] = c.schemaToSwagger2(path+"/"+k, value)
+		case "allOf":
+			l := []interface{}{}
+			for i, s := range asSlice(value) {
+				l = append(l, c.schemaToSwagger2(fmt.Sprintf("%s/%s/%d", path, k, i), s))
+			}
+			out[k] = l
+		case "discriminator":
+			d := asMap(value)
+			out[k] = d["propertyName"]
+			if _, ok := d["mapping"]; ok {
+				c.warn(path+"/discriminator/mapping", "discriminator mapping is not supported by Swagger 2.0 and removed")
+			}
+		default:
+			out[k] = value
+		}
+	}
+	return out
+}
+
+func (c *definitionConverter) securitySchemesToSwagger2(path string, schemes map[string]interface{}) map[string]interface{} {
+	out := map[string]interface{}{}
+	for _, name := range sortedKeys(schemes) {
+		scheme := asMap(schemes[name])
+		p := path + "/" + escapeJSONPointer(name)
+		converted := map[string]interface{}{}
+		if description, ok := scheme["description"]; ok {
+			converted["description"] = description
+		}
+		switch scheme["type"] {
+		case "http":
+			if strings.EqualFold(fmt.Sprint(scheme["scheme"]), "basic") {
+				converted["type"] = "basic"
+			} else {
+				c.warn(p, "http %v authentication is not supported by Swagger 2.0; converted to the Authorization header api key", scheme["scheme"])
+				converted["type"] = "apiKey"
+				converted["name"] = "Authorization"
+				converted["in"] = "header"
+			}
+		case "apiKey":
+			if scheme["in"] == "cookie" {
+				c.warn(p, "cookie api keys are not supported by Swagger 2.0 and removed")
+				continue
+			}
+			converted["type"] = "apiKey"
+			converted["name"] = scheme["name"]
+			converted["in"] = scheme["in"]
+		case "oauth2":
+			flows := asMap(scheme["flows"])
+			first := true
+			for _, f := range oauth2Flows {
+				flow, ok := flows[f[0]]
+				if !ok {
+					continue
+				}
+				def := map[string]interface{}{
+					"type": "oauth2",
+					"flow": f[1],
+				}
+				for _, k := range []string{"authorizationUrl", "tokenUrl", "scopes"} {
+					if v, ok := asMap(flow)[k]; ok {
+						def[k] = v
+					}
+				}
+				if _, ok := def["scopes"]; !ok {
+					def["scopes"] = map[string]interface{}{}
+				}
+				if description, ok := scheme["description"]; ok {
+					def["description"] = description
+				}
+				if first {
+					converted = def
+					first = false
+				} else {
+					c.warn(p+"/flows/"+f[0], "Swagger 2.0 supports one flow per security scheme; the flow is defined as %s_%s", name, f[0])
+					out[name+"_"+f[0]] = def
+				}
+			}
+			if first {
+				c.warn(p, "oauth2 security scheme without flows is removed")
+				continue
+			}
+		default:
+			c.warn(p, "%v security scheme is not supported by Swagger 2.0 and removed", scheme["type"])
+			continue
+		}
+		out[name] = converted
+	}
+	return out
+}
+
+func (c *definitionConverter) refToSwagger2(path string, ref string) string {
+	for _, r := range [][2]string{
+		{"#/components/schemas/", "#/definitions/"},
+		{"#/components/parameters/", "#/parameters/"},
+		{"#/components/responses/", "#/responses/"},
+	} {
+		if strings.HasPrefix(ref, r[0]) {
+			return r[1] + strings.TrimPrefix(ref, r[0])
+		}
+	}
+	if strings.HasPrefix(ref, "#/") {
+		c.warn(path, "reference %s cannot be converted to Swagger 2.0", ref)
+	}
+	return ref
+}
+
+// Swagger 2.0 to OpenAPI 3
+
+func (c *definitionConverter) swagger2ToOpenAPI3() map[string]interface{} {
+	out := map[string]interface{}{"openapi": "3.0.0"}
+	for _, k := range sortedKeys(c.root) {
+		v := c.root[k]
+		switch k {
+		case "swagger", "host", "basePath", "schemes", "consumes", "produces", "paths",
+			"definitions", "parameters", "responses", "securityDefinitions":
+		case "info", "tags", "externalDocs", "security":
+			out[k] = v
+		default:
+			if strings.HasPrefix(k, "x-") {
+				out[k] = v
+			} else {
+				c.warn("/"+k, "unsupported field is removed")
+			}
+		}
+	}
+
+	// servers
+	host, _ := c.root["host"].(string)
+	basePath, _ := c.root["basePath"].(string)
+	servers := []interface{}{}
+	if host == "" {
+		if basePath != "" {
+			servers = append(servers, map[string]interface{}{"url": basePath})
+		}
+	} else {
+		schemes := asSlice(c.root["schemes"])
+		if len(schemes) == 0 {
+			schemes = []interface{}{"https"}
+		}
+		for _, scheme := range schemes {
+			servers = append(servers, map[string]interface{}{"url": fmt.Sprintf("%v://%s%s", scheme, host, basePath)})
+		}
+	}
+	if len(servers) > 0 {
+		out["servers"] = servers
+	}
+
+	// components
+	components := map[string]interface{}{}
+	if definitions := asMap(c.root["definitions"]); len(definitions) > 0 {
+		schemas := map[string]interface{}{}
+		for _, name := range sortedKeys(definitions) {
+			schemas[name] = c.schemaToOpenAPI3("/definitions/"+escapeJSONPointer(name), definitions[name])
+		}
+		components["schemas"] = schemas
+	}
+	if parameters := asMap(c.root["parameters"]); len(parameters) > 0 {
+		params := map[string]interface{}{}
+		requestBodies := map[string]interface{}{}
+		for _, name := range sortedKeys(parameters) {
+			p := asMap(parameters[name])
+			path := "/parameters/" + escapeJSONPointer(name)
+			switch p["in"] {
+			case "body":
+				requestBodies[name] = c.bodyParameterToOpenAPI3(path, p, asSlice(c.root["consumes"]))
+			case "formData":
+				c.warn(path, "form data parameters cannot be shared in OpenAPI 3 and removed")
+			default:
+				params[name] = c.parameterToOpenAPI3(path, p)
+			}
+		}
+		if len(params) > 0 {
+			components["parameters"] = params
+		}
+		if len(requestBodies) > 0 {
+			components["requestBodies"] = requestBodies
+		}
+	}
+	if responses := asMap(c.root["responses"]); len(responses) > 0 {
+		r := map[string]interface{}{}
+		for _, name := range sortedKeys(responses) {
+			r[name] = c.responseToOpenAPI3("/responses/"+escapeJSONPointer(name), responses[name], asSlice(c.root["produces"]))
+		}
+		components["responses"] = r
+	}
+	if securityDefinitions := asMap(c.root["securityDefinitions"]); len(securityDefinitions) > 0 {
+		components["securitySchemes"] = c.securityDefinitionsToOpenAPI3(securityDefinitions)
+	}
+	if len(components) > 0 {
+		out["components"] = components
+	}
+
+	paths := map[string]interface{}{}
+	for _, p := range sortedKeys(asMap(c.root["paths"])) {
+		paths[p] = c.pathItemToOpenAPI3("/paths/"+escapeJSONPointer(p), asMap(asMap(c.root["paths"])[p]))
+	}
+	out["paths"] = paths
+	return out
+}
+
+func (c *definitionConverter) pathItemToOpenAPI3(path string, item map[string]interface{}) map[string]interface{} {
+	out := map[string]interface{}{}
+	var bodyParams []interface{}
+	for _, k := range sortedKeys(item) {
+		switch {
+		case k == "parameters":
+			params := []interface{}{}
+			for i, p := range asSlice(item[k]) {
+				if in := c.parameterLocation(p); in == "body" || in == "formData" {
+					// moved to the operations
+					bodyParams = append(bodyParams, p)
+					continue
+				}
+				ppath := fmt.Sprintf("%s/%s/%d", path, k, i)
+				if ref, ok := asMap(p)["$ref"].(string); ok {
+					params = append(params, map[string]interface{}{"$ref": c.refToOpenAPI3(ppath, ref)})
+					continue
+				}
+				params = append(params, c.parameterToOpenAPI3(ppath, asMap(p)))
+			}
+			if len(params) > 0 {
+				out[k] = params
+			}
+		case containsString(swagger2Operations, k), k == "$ref":
+		case strings.HasPrefix(k, "x-"):
+			out[k] = item[k]
+		default:
+			c.warn(path+"/"+k, "unsupported field is removed")
+		}
+	}
+	for _, k := range swagger2Operations {
+		if op, ok := item[k]; ok {
+			out[k] = c.operationToOpenAPI3(path+"/"+k, asMap(op), bodyParams)
+		}
+	}
+	if _, ok := item["$ref"]; ok {
+		c.warn(path+"/$ref", "path item references are not converted")
+		out["$ref"] = item["$ref"]
+	}
+	return out
+}
+
+func (c *definitionConverter) operationToOpenAPI3(path string, op map[string]interface{}, pathBodyParams []interface{}) map[string]interface{} {
+	out := map[string]interface{}{}
+	consumes := asSlice(c.root["consumes"])
+	if v, ok := op["consumes"]; ok {
+		consumes = asSlice(v)
+	}
+	produces := asSlice(c.root["produces"])
+	if v, ok := op["produces"]; ok {
+		produces = asSlice(v)
+	}
+	for _, k := range sortedKeys(op) {
+		v := op[k]
+		switch k {
+		case "tags", "summary", "description", "externalDocs", "operationId", "deprecated", "security":
+			out[k] = v
+		case "consumes", "produces", "parameters":
+		case "responses":
+			responses := map[string]interface{}{}
+			for _, code := range sortedKeys(asMap(v)) {
+				responses[code] = c.responseToOpenAPI3(path+"/responses/"+code, asMap(v)[code], produces)
+			}
+			out[k] = responses
+		case "schemes":
+			c.warn(path+"/"+k, "operation schemes are not supported by OpenAPI 3 and removed")
+		default:
+			if strings.HasPrefix(k, "x-") {
+				out[k] = v
+			} else {
+				c.warn(path+"/"+k, "unsupported field is removed")
+			}
+		}
+	}
+
+	params := []interface{}{}
+	var body map[string]interface{}
+	formParams := []map[string]interface{}{}
+	// the operation parameters override the path level ones of the same name and location
+	overridden := map[string]bool{}
+	for _, v := range asSlice(op["parameters"]) {
+		overridden[c.parameterKey(v)] = true
+	}
+	all := []interface{}{}
+	for _, v := range pathBodyParams {
+		if !overridden[c.parameterKey(v)] {
+			all = append(all, v)
+		}
+	}
+	pathParams := len(all)
+	all = append(all, asSlice(op["parameters"])...)
+	for i, v := range all {
+		p := asMap(v)
+		ppath := fmt.Sprintf("%s/parameters/%d", path, i-pathParams)
+		if i < pathParams {
+			ppath = path[:strings.LastIndex(path, "/")] + "/parameters"
+		}
+		if ref, ok := p["$ref"].(string); ok {
+			resolved := c.resolveRef(ref)
+			switch {
+			case resolved == nil:
+				c.warn(ppath, "unresolvable reference %s is removed", ref)
+			case resolved["in"] == "body":
+				body = map[string]interface{}{"$ref": "#/components/requestBodies/" + strings.TrimPrefix(ref, "#/parameters/")}
+			case resolved["in"] == "formData":
+				formParams = append(formParams, resolved)
+			default:
+				params = append(params, map[string]interface{}{"$ref": c.refToOpenAPI3(ppath, ref)})
+			}
+			continue
+		}
+		switch p["in"] {
+		case "body":
+			body = c.bodyParameterToOpenAPI3(ppath, p, consumes)
+		case "formData":
+			formParams = append(formParams, p)
+		default:
+			params = append(params, c.parameterToOpenAPI3(ppath, p))
+		}
+	}
+	if len(params) > 0 {
+		out["parameters"] = params
+	}
+	if len(formParams) > 0 {
+		body = c.formParametersToOpenAPI3(path+"/parameters", formParams, consumes)
+	}
+	if body != nil {
+		out["requestBody"] = body
+	}
+	return out
+}
+
+// parameterKey returns "IN:NAME" of the parameter which identifies the parameter in the operation.
+func (c *definitionConverter) parameterKey(v interface{}) string {
+	p := asMap(v)
+	if ref, ok := p["$ref"].(string); ok {
+		p = c.resolveRef(ref)
+	}
+	return fmt.Sprintf("%v:%v", p["in"], p["name"])
+}
+
+func (c *definitionConverter) parameterLocation(v interface{}) string {
+	p := asMap(v)
+	if ref, ok := p["$ref"].(string); ok {
+		p = c.resolveRef(ref)
+	}
+	in, _ := p["in"].(string)
+	return in
+}
+
+func (c *definitionConverter) parameterToOpenAPI3(path string, p map[string]interface{}) map[string]interface{} {
+	out := map[string]interface{}{}
+	schema := map[string]interface{}{}
+	for _, k := range sortedKeys(p) {
+		switch {
+		case k == "name", k == "in", k == "description", k == "required", k == "allowEmptyValue":
+			out[k] = p[k]
+		case k == "x-example":
+			out["example"] = p[k]
+		case k == "collectionFormat":
+		case containsString(swagger2ParameterSchemaFields, k):
+			schema[k] = p[k]
+		case strings.HasPrefix(k, "x-"):
+			out[k] = p[k]
+		default:
+			c.warn(path+"/"+k, "unsupported field is removed")
+		}
+	}
+	if len(schema) > 0 {
+		out["schema"] = c.schemaToOpenAPI3(path, schema)
+	}
+	if p["type"] == "array" {
+		in := fmt.Sprint(p["in"])
+		switch p["collectionFormat"] {
+		case nil, "csv":
+			if in == "query" {
+				out["style"] = "form"
+				out["explode"] = false
+			} else {
+				out["style"] = "simple"
+			}
+		case "ssv":
+			out["style"] = "spaceDelimited"
+		case "pipes":
+			out["style"] = "pipeDelimited"
+		case "multi":
+			out["style"] = "form"
+			out["explode"] = true
+		default:
+			c.warn(path+"/collectionFormat", "collection format %v is not supported by OpenAPI 3", p["collectionFormat"])
+		}
+	}
+	return out
+}
+
+func (c *definitionConverter) bodyParameterToOpenAPI3(path string, p map[string]interface{}, consumes []interface{}) map[string]interface{} {
+	if len(consumes) == 0 {
+		consumes = []interface{}{"application/json"}
+	}
+	schema := c.schemaToOpenAPI3(path+"/schema", p["schema"])
+	content := map[string]interface{}{}
+	for _, mediaType := range consumes {
+		content[fmt.Sprint(mediaType)] = map[string]interface{}{"schema": schema}
+	}
+	out := map[string]interface{}{"content": content}
+	if description, ok := p["description"]; ok {
+		out["description"] = description
+	}
+	if required, ok := p["required"]; ok {
+		out["required"] = required
+	}
+	if name, ok := p["name"].(string); ok && name != "body" {
+		out["x-codegen-request-body-name"] = name
+	}
+	return out
+}
+
+func (c *definitionConverter) formParametersToOpenAPI3(path string, params []map[string]interface{}, consumes []interface{}) map[string]interface{} {
+	properties := map[string]interface{}{}
+	required := []interface{}{}
+	multipart := containsValue(consumes, "multipart/form-data")
+	for i, p := range params {
+		name := fmt.Sprint(p["name"])
+		var property map[string]interface{}
+		if p["type"] == "file" {
+			multipart = true
+			property = map[string]interface{}{"type": "string", "format": "binary"}
+		} else {
+			property = asMap(c.parameterToOpenAPI3(fmt.Sprintf("%s/%d", path, i), p)["schema"])
+		}
+		if description, ok := p["description"]; ok {
+			property["description"] = description
+		}
+		properties[name] = property
+		if r, _ := p["required"].(bool); r {
+			required = append(required, name)
+		}
+	}
+	schema := map[string]interface{}{
+		"type":       "object",
+		"properties": properties,
+	}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+	mediaType := "application/x-www-form-urlencoded"
+	if multipart {
+		mediaType = "multipart/form-data"
+	}
+	return map[string]interface{}{
+		"content": map[string]interface{}{
+			mediaType: map[string]interface{}{"schema": schema},
+		},
+	}
+}
+
+func (c *definitionConverter) responseToOpenAPI3(path string, v interface{}, produces []interface{}) interface{} {
+	r := asMap(v)
+	if ref, ok := r["$ref"].(string); ok {
+		return map[string]interface{}{"$ref": c.refToOpenAPI3(path, ref)}
+	}
+	if len(produces) == 0 {
+		produces = []interface{}{"application/json"}
+	}
+	out := map[string]interface{}{"description": ""}
+	for _, k := range sortedKeys(r) {
+		switch k {
+		case "description":
+			out[k] = r[k]
+		case "schema", "examples":
+		case "headers":
+			headers := map[string]interface{}{}
+			for _, name := range sortedKeys(asMap(r[k])) {
+				header := asMap(asMap(r[k])[name])
+				h := map[string]interface{}{}
+				schema := map[string]interface{}{}
+				for _, f := range sortedKeys(header) {
+					if f == "description" {
+						h[f] = header[f]
+					} else {
+						schema[f] = header[f]
+					}
+				}
+				h["schema"] = c.schemaToOpenAPI3(path+"/headers/"+escapeJSONPointer(name), schema)
+				headers[name] = h
+			}
+			out[k] = headers
+		default:
+			if strings.HasPrefix(k, "x-") {
+				out[k] = r[k]
+			} else {
+				c.warn(path+"/"+k, "unsupported field is removed")
+			}
+		}
+	}
+	schema, hasSchema := r["schema"]
+	examples := asMap(r["examples"])
+	if hasSchema || len(examples) > 0 {
+		content := map[string]interface{}{}
+		for _, mediaType := range produces {
+			m := map[string]interface{}{}
+			if hasSchema {
+				m["schema"] = c.schemaToOpenAPI3(path+"/schema", schema)
+			}
+			if example, ok := examples[fmt.Sprint(mediaType)]; ok {
+				m["example"] = example
+			}
+			content[fmt.Sprint(mediaType)] = m
+		}
+		out["content"] = content
+	}
+	return out
+}
+
+func (c *definitionConverter) schemaToOpenAPI3(path string, v interface{}) interface{} {
+	schema, ok := v.(map[string]interface{})
+	if !ok {
+		return v
+	}
+	out := map[string]interface{}{}
+	for _, k := range sortedKeys(schema) {
+		value := schema[k]
+		switch k {
+		case "$ref":
+			out[k] = c.refToOpenAPI3(path, fmt.Sprint(value))
+		case "x-nullable":
+			out["nullable"] = value
+		case "collectionFormat":
+		case "type":
+			if value == "file" {
+				out["type"] = "string"
+				out["format"] = "binary"
+			} else {
+				out[k] = value
+			}
+		case "properties":
+			properties := map[string]interface{}{}
+			for _, name := range sortedKeys(asMap(value)) {
+				properties[name] = c.schemaToOpenAPI3(path+"/properties/"+escapeJSONPointer(name), asMap(value)[name])
+			}
+			out[k] = properties
+		case "items", "additionalProperties":
+			out[k] = c.schemaToOpenAPI3(path+"/"+k, value)
+		case "allOf":
+			l := []interface{}{}
+			for i, s := range asSlice(value) {
+				l = append(l, c.schemaToOpenAPI3(fmt.Sprintf("%s/%s/%d", path, k, i), s))
+			}
+			out[k] = l
+		case "discriminator":
+			out[k] = map[string]interface{}{"propertyName": value}
+		default:
+			out[k] = value
+		}
+	}
+	return out
+}
+
+func (c *definitionConverter) securityDefinitionsToOpenAPI3(definitions map[string]interface{}) map[string]interface{} {
+	out := map[string]interface{}{}
+	for _, name := range sortedKeys(definitions) {
+		def := asMap(definitions[name])
+		scheme := map[string]interface{}{}
+		if description, ok := def["description"]; ok {
+			scheme["description"] = description
+		}
+		switch def["type"] {
+		case "basic":
+			scheme["type"] = "http"
+			scheme["scheme"] = "basic"
+		case "apiKey":
+			scheme["type"] = "apiKey"
+			scheme["name"] = def["name"]
+			scheme["in"] = def["in"]
+		case "oauth2":
+			flow := map[string]interface{}{}
+			for _, k := range []string{"authorizationUrl", "tokenUrl", "scopes"} {
+				if v, ok := def[k]; ok {
+					flow[k] = v
+				}
+			}
+			if _, ok := flow["scopes"]; !ok {
+				flow["scopes"] = map[string]interface{}{}
+			}
+			flowName := fmt.Sprint(def["flow"])
+			for _, f := range oauth2Flows {
+				if f[1] == flowName {
+					flowName = f[0]
+				}
+			}
+			scheme["type"] = "oauth2"
+			scheme["flows"] = map[string]interface{}{flowName: flow}
+		default:
+			c.warn("/securityDefinitions/"+escapeJSONPointer(name), "%v security definition is not supported and removed", def["type"])
+			continue
+		}
+		out[name] = scheme
+	}
+	return out
+}
+
+func (c *definitionConverter) refToOpenAPI3(path string, ref string) string {
+	for _, r := range [][2]string{
+		{"#/definitions/", "#/components/schemas/"},
+		{"#/parameters/", "#/components/parameters/"},
+		{"#/responses/", "#/components/responses/"},
+	} {
+		if strings.HasPrefix(ref, r[0]) {
+			return r[1] + strings.TrimPrefix(ref, r[0])
+		}
+	}
+	if strings.HasPrefix(ref, "#/") {
+		c.warn(path, "reference %s cannot be converted to OpenAPI 3", ref)
+	}
+	return ref
+}
+
+// resolveRef resolves the local reference in the source definition.
+func (c *definitionConverter) resolveRef(ref string) map[string]interface{} {
+	v, ok := resolveJSONPointer(c.root, ref)
+	if !ok {
+		return nil
+	}
+	return asMap(v)
+}
@@ -0,0 +1,256 @@
+package wso2am
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestAPIDefinitionConvertTo(t *testing.T) {
+	tests := []struct {
+		name     string
+		to       APIDefinitionSpec
+		def      string
+		want     string
+		warnings []string
+	}{
+		{
+			name: "Swagger 2.0 to OpenAPI 3",
+			to:   APIDefinitionSpecOpenAPI3,
+			def: `{
+  "swagger": "2.0",
+  "info": {"title": "pets", "version": "1.0.0"},
+  "host": "localhost:8243",
+  "basePath": "/pets/1.0.0",
+  "schemes": ["https"],
+  "paths": {
+    "/pets": {
+      "post": {
+        "consumes": ["application/json"],
+        "parameters": [
+          {"name": "limit", "in": "query", "type": "array", "items": {"type": "integer"}},
+          {"name": "pet", "in": "body", "required": true, "schema": {"$ref": "#/definitions/Pet"}}
+        ],
+        "responses": {"200": {"description": "OK", "schema": {"$ref": "#/definitions/Pet"}}}
+      }
+    }
+  },
+  "definitions": {
+    "Pet": {"type": "object", "properties": {"name": {"type": "string", "x-nullable": true}}}
+  }
+}`,
+			want: `{
+  "openapi": "3.0.0",
+  "info": {"title": "pets", "version": "1.0.0"},
+  "servers": [{"url": "https://localhost:8243/pets/1.0.0"}],
+  "paths": {
+    "/pets": {
+      "post": {
+        "parameters": [
+          {"name": "limit", "in": "query", "schema": {"type": "array", "items": {"type": "integer"}}, "style": "form", "explode": false}
+        ],
+        "requestBody": {
+          "content": {"application/json": {"schema": {"$ref": "#/components/schemas/Pet"}}},
+          "required": true,
+          "x-codegen-request-body-name": "pet"
+        },
+        "responses": {
+          "200": {"description": "OK", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/Pet"}}}}
+        }
+      }
+    }
+  },
+  "components": {
+    "schemas": {
+      "Pet": {"type": "object", "properties": {"name": {"type": "string", "nullable": true}}}
+    }
+  }
+}`,
+			warnings: []string{},
+		},
+		{
+			name: "operation parameters override the path parameters",
+			to:   APIDefinitionSpecOpenAPI3,
+			def: `{
+  "swagger": "2.0",
+  "info": {"title": "pets", "version": "1.0.0"},
+  "paths": {
+    "/pets": {
+      "parameters": [
+        {"name": "name", "in": "formData", "type": "string"},
+        {"name": "tag", "in": "formData", "type": "string"}
+      ],
+      "post": {
+        "parameters": [
+          {"name": "name", "in": "formData", "type": "string", "required": true},
+          {"$ref": "#/parameters/photo"},
+          {"$ref": "#/parameters/missing"}
+        ],
+        "responses": {"200": {"description": "OK"}}
+      }
+    }
+  },
+  "parameters": {
+    "photo": {"name": "photo", "in": "formData", "type": "file"}
+  }
+}`,
+			want: `{
+  "openapi": "3.0.0",
+  "info": {"title": "pets", "version": "1.0.0"},
+  "paths": {
+    "/pets": {
+      "post": {
+        "requestBody": {
+          "content": {
+            "multipart/form-data": {
+              "schema": {
+                "type": "object",
+                "properties": {
+                  "name": {"type": "string"},
+                  "photo": {"type": "string", "format": "binary"},
+                  "tag": {"type": "string"}
+                },
+                "required": ["name"]
+              }
+            }
+          }
+        },
+        "responses": {"200": {"description": "OK"}}
+      }
+    }
+  }
+}`,
+			warnings: []string{
+				"/parameters/photo: form data parameters cannot be shared in OpenAPI 3 and removed",
+				"/paths/~1pets/post/parameters/2: unresolvable reference #/parameters/missing is removed",
+			},
+		},
+		{
+			name: "OpenAPI 3 to Swagger 2.0",
+			to:   APIDefinitionSpecSwagger2,
+			def: `{
+  "openapi": "3.0.0",
+  "info": {"title": "pets", "version": "1.0.0"},
+  "servers": [{"url": "https://localhost:8243/pets/1.0.0"}],
+  "paths": {
+    "/pets": {
+      "get": {
+        "parameters": [
+          {"name": "tags", "in": "query", "schema": {"type": "array", "items": {"type": "string"}}},
+          {"$ref": "#/components/parameters/session"},
+          {"$ref": "#/components/parameters/limit"}
+        ],
+        "responses": {
+          "200": {"description": "OK", "content": {"application/json": {"schema": {"type": "array", "items": {"$ref": "#/components/schemas/Pet"}}}}}
+        }
+      },
+      "post": {
+        "requestBody": {"required": true, "content": {"application/json": {"schema": {"$ref": "#/components/schemas/Pet"}}}},
+        "responses": {"201": {"description": "Created"}}
+      }
+    }
+  },
+  "components": {
+    "schemas": {
+      "Pet": {"type": "object", "properties": {"name": {"type": "string"}}}
+    },
+    "parameters": {
+      "limit": {"name": "limit", "in": "query", "schema": {"type": "integer"}},
+      "session": {"name": "session", "in": "cookie", "schema": {"type": "string"}}
+    }
+  }
+}`,
+			want: `{
+  "swagger": "2.0",
+  "info": {"title": "pets", "version": "1.0.0"},
+  "host": "localhost:8243",
+  "basePath": "/pets/1.0.0",
+  "schemes": ["https"],
+  "paths": {
+    "/pets": {
+      "get": {
+        "parameters": [
+          {"name": "tags", "in": "query", "type": "array", "items": {"type": "string"}, "collectionFormat": "multi"},
+          {"$ref": "#/parameters/limit"}
+        ],
+        "produces": ["application/json"],
+        "responses": {
+          "200": {"description": "OK", "schema": {"type": "array", "items": {"$ref": "#/definitions/Pet"}}}
+        }
+      },
+      "post": {
+        "consumes": ["application/json"],
+        "parameters": [
+          {"name": "body", "in": "body", "required": true, "schema": {"$ref": "#/definitions/Pet"}}
+        ],
+        "responses": {"201": {"description": "Created"}}
+      }
+    }
+  },
+  "definitions": {
+    "Pet": {"type": "object", "properties": {"name": {"type": "string"}}}
+  },
+  "parameters": {
+    "limit": {"name": "limit", "in": "query", "type": "integer"}
+  }
+}`,
+			warnings: []string{
+				"/components/parameters/session: cookie parameters are not supported by Swagger 2.0 and removed",
+				"/paths/~1pets/get/parameters/1: reference to the cookie parameter #/components/parameters/session is removed",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			converted, warnings, err := APIDefinition(tt.def).ConvertTo(tt.to)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			got, err := converted.parse()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			want, err := APIDefinition(tt.want).parse()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("converted = %s, want %s", converted, tt.want)
+			}
+			gotWarnings := []string{}
+			for _, w := range warnings {
+				gotWarnings = append(gotWarnings, w.String())
+			}
+			if !reflect.DeepEqual(gotWarnings, tt.warnings) {
+				t.Errorf("warnings = %q, want %q", gotWarnings, tt.warnings)
+			}
+		})
+	}
+}
+
+func TestClientConvertAPIDefinition(t *testing.T) {
+	const openAPI3 = `{"openapi": "3.0.0", "info": {"title": "pets", "version": "1.0.0"}, "paths": {}}`
+	tests := []struct {
+		name       string
+		apiVersion string
+		want       APIDefinitionSpec
+	}{
+		{name: "OpenAPI 3 is accepted by v1", apiVersion: "v1", want: APIDefinitionSpecOpenAPI3},
+		{name: "OpenAPI 3 is converted for v0.x", apiVersion: "v0.14", want: APIDefinitionSpecSwagger2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Client{config: &Config{APIVersion: tt.apiVersion}}
+			def, _, err := c.ConvertAPIDefinition(APIDefinition(openAPI3))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			spec, err := def.Spec()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if spec != tt.want {
+				t.Errorf("spec = %s, want %s", spec, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,394 @@
+package wso2am
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+type (
+	// APIDefinitionChange is a difference between two API definitions.
+	APIDefinitionChange struct {
+		// Breaking is true if the change may break the existing consumers.
+		Breaking bool `json:"breaking"`
+		// Operation is the operation like "GET /pets".  It is empty for the changes of the paths.
+		Operation string `json:"operation,omitempty"`
+		Location  string `json:"location"`
+		Message   string `json:"message"`
+	}
+	definitionDiff struct {
+		old, new map[string]interface{}
+		changes  []APIDefinitionChange
+		// visited is the pairs of the schema references being compared, to stop the recursion of the recursive schemas.
+		// The pairs are removed after the comparison, so the shared schemas are compared in every operation.
+		visited map[string]bool
+	}
+	diffDirection int
+)
+
+const (
+	// diffRequest compares the schemas sent by the consumers.
+	diffRequest diffDirection = iota
+	// diffResponse compares the schemas received by the consumers.
+	diffResponse
+)
+
+func (c APIDefinitionChange) String() string {
+	kind := "non-breaking"
+	if c.Breaking {
+		kind = "breaking"
+	}
+	if c.Operation == "" {
+		return fmt.Sprintf("[%s] %s: %s", kind, c.Location, c.Message)
+	}
+	return fmt.Sprintf("[%s] %s %s: %s", kind, c.Operation, c.Location, c.Message)
+}
+
+// HasBreakingChanges returns true if any of the changes is breaking.
+func HasBreakingChanges(changes []APIDefinitionChange) bool {
+	for _, c := range changes {
+		if c.Breaking {
+			return true
+		}
+	}
+	return false
+}
+
+// DiffAPIDefinitions compares the API definitions and classifies the changes into breaking and non-breaking.
+//
+// The breaking changes are removed paths, operations, responses and media types,
+// new required parameters and properties, changed types and narrowed enums of the requests,
+// and the changes of the composed schemas (allOf, oneOf and anyOf) narrowing the requests or widening the responses.
+func DiffAPIDefinitions(oldDef, newDef APIDefinition) ([]APIDefinitionChange, error) {
+	// compare in OpenAPI 3 which is the superset of Swagger 2.0
+	oldDoc, err := oldDef.openAPI3Document()
+	if err != nil {
+		return nil, err
+	}
+	newDoc, err := newDef.openAPI3Document()
+	if err != nil {
+		return nil, err
+	}
+	d := &definitionDiff{
+		old:     oldDoc,
+		new:     newDoc,
+		changes: []APIDefinitionChange{},
+		visited: map[string]bool{},
+	}
+	d.diff()
+	return d.changes, nil
+}
+
+// DiffAPIDefinition compares the current definition of the API with the definition.
+func (c *Client) DiffAPIDefinition(id string, definition APIDefinition) ([]APIDefinitionChange, error) {
+	current, err := c.currentAPIDefinition(id)
+	if err != nil {
+		return nil, err
+	}
+	return DiffAPIDefinitions(current, definition)
+}
+
+func (d APIDefinition) openAPI3Document() (map[string]interface{}, error) {
+	converted, _, err := d.ToOpenAPI3()
+	if err != nil {
+		return nil, err
+	}
+	return converted.parse()
+}
+
+func (d *definitionDiff) add(breaking bool, operation, location string, format string, args ...interface{}) {
+	d.changes = append(d.changes, APIDefinitionChange{
+		Breaking:  breaking,
+		Operation: operation,
+		Location:  location,
+		Message:   fmt.Sprintf(format, args...),
+	})
+}
+
+func (d *definitionDiff) diff() {
+	oldPaths := asMap(d.old["paths"])
+	newPaths := asMap(d.new["paths"])
+	for _, p := range unionKeys(oldPaths, newPaths) {
+		oldItem, inOld := oldPaths[p]
+		newItem, inNew := newPaths[p]
+		switch {
+		case !inNew:
+			d.add(true, "", p, "path removed")
+		case !inOld:
+			d.add(false, "", p, "path added")
+		default:
+			d.diffPathItem(p, asMap(oldItem), asMap(newItem))
+		}
+	}
+}
+
+func (d *definitionDiff) diffPathItem(p string, oldItem, newItem map[string]interface{}) {
+	for _, method := range append(append([]string{}, swagger2Operations...), "trace") {
+		oldOp, inOld := oldItem[method].(map[string]interface{})
+		newOp, inNew := newItem[method].(map[string]interface{})
+		operation := strings.ToUpper(method) + " " + p
+		switch {
+		case inOld && !inNew:
+			d.add(true, operation, "", "operation removed")
+		case !inOld && inNew:
+			d.add(false, operation, "", "operation added")
+		case inOld && inNew:
+			d.diffOperation(operation, oldItem, oldOp, newItem, newOp)
+		}
+	}
+}
+
+func (d *definitionDiff) diffOperation(operation string, oldItem, oldOp, newItem, newOp map[string]interface{}) {
+	if oldDeprecated, _ := oldOp["deprecated"].(bool); !oldDeprecated {
+		if newDeprecated, _ := newOp["deprecated"].(bool); newDeprecated {
+			d.add(false, operation, "", "operation deprecated")
+		}
+	}
+
+	// parameters
+	oldParams := d.parameters(d.old, oldItem, oldOp)
+	newParams := d.parameters(d.new, newItem, newOp)
+	for _, key := range unionParameterKeys(oldParams, newParams) {
+		oldParam, inOld := oldParams[key]
+		newParam, inNew := newParams[key]
+		location := "parameter " + key
+		newRequired, _ := newParam["required"].(bool)
+		oldRequired, _ := oldParam["required"].(bool)
+		switch {
+		case !inNew:
+			d.add(false, operation, location, "parameter removed")
+		case !inOld:
+			if newRequired {
+				d.add(true, operation, location, "required parameter added")
+			} else {
+				d.add(false, operation, location, "optional parameter added")
+			}
+		default:
+			if newRequired && !oldRequired {
+				d.add(true, operation, location, "parameter became required")
+			} else if !newRequired && oldRequired {
+				d.add(false, operation, location, "parameter became optional")
+			}
+			d.diffSchema(operation, location, oldParam["schema"], newParam["schema"], diffRequest)
+		}
+	}
+
+	// request body
+	oldBody := d.resolve(d.old, oldOp["requestBody"])
+	newBody := d.resolve(d.new, newOp["requestBody"])
+	oldBodyRequired, _ := oldBody["required"].(bool)
+	newBodyRequired, _ := newBody["required"].(bool)
+	switch {
+	case len(oldBody) == 0 && len(newBody) > 0:
+		d.add(newBodyRequired, operation, "request body", "request body added")
+	case len(oldBody) > 0 && len(newBody) == 0:
+		d.add(false, operation, "request body", "request body removed")
+	case len(oldBody) > 0:
+		if newBodyRequired && !oldBodyRequired {
+			d.add(true, operation, "request body", "request body became required")
+		}
+		d.diffContent(operation, "request body", asMap(oldBody["content"]), asMap(newBody["content"]), diffRequest)
+	}
+
+	// responses
+	oldResponses := asMap(oldOp["responses"])
+	newResponses := asMap(newOp["responses"])
+	for _, code := range unionKeys(oldResponses, newResponses) {
+		location := "response " + code
+		_, inOld := oldResponses[code]
+		_, inNew := newResponses[code]
+		switch {
+		case !inNew:
+			d.add(true, operation, location, "response removed")
+		case !inOld:
+			d.add(false, operation, location, "response added")
+		default:
+			oldResponse := d.resolve(d.old, oldResponses[code])
+			newResponse := d.resolve(d.new, newResponses[code])
+			d.diffContent(operation, location, asMap(oldResponse["content"]), asMap(newResponse["content"]), diffResponse)
+		}
+	}
+}
+
+// parameters returns the parameters of the operation including the path level ones by "IN:NAME".
+func (d *definitionDiff) parameters(doc, item, op map[string]interface{}) map[string]map[string]interface{} {
+	params := map[string]map[string]interface{}{}
+	for _, l := range [][]interface{}{asSlice(item["parameters"]), asSlice(op["parameters"])} {
+		for _, p := range l {
+			param := d.resolve(doc, p)
+			params[fmt.Sprintf("%v:%v", param["in"], param["name"])] = param
+		}
+	}
+	return params
+}
+
+func (d *definitionDiff) diffContent(operation, location string, oldContent, newContent map[string]interface{}, direction diffDirection) {
+	for _, mediaType := range unionKeys(oldContent, newContent) {
+		oldMedia, inOld := oldContent[mediaType]
+		newMedia, inNew := newContent[mediaType]
+		switch {
+		case !inNew:
+			d.add(true, operation, location, "media type %s removed", mediaType)
+		case !inOld:
+			d.add(false, operation, location, "media type %s added", mediaType)
+		default:
+			d.diffSchema(operation, location+" "+mediaType, asMap(oldMedia)["schema"], asMap(newMedia)["schema"], direction)
+		}
+	}
+}
+
+func (d *definitionDiff) diffSchema(operation, location string, oldValue, newValue interface{}, direction diffDirection) {
+	if oldValue == nil || newValue == nil {
+		if oldValue == nil && newValue != nil {
+			d.add(false, operation, location, "schema added")
+		} else if oldValue != nil && newValue == nil {
+			d.add(direction == diffResponse, operation, location, "schema removed")
+		}
+		return
+	}
+	oldRef, _ := asMap(oldValue)["$ref"].(string)
+	newRef, _ := asMap(newValue)["$ref"].(string)
+	if oldRef != "" || newRef != "" {
+		key := fmt.Sprintf("%d:%s:%s", direction, oldRef, newRef)
+		if d.visited[key] {
+			return
+		}
+		d.visited[key] = true
+		defer delete(d.visited, key)
+	}
+	oldSchema := d.resolve(d.old, oldValue)
+	newSchema := d.resolve(d.new, newValue)
+
+	oldType, _ := oldSchema["type"].(string)
+	newType, _ := newSchema["type"].(string)
+	if oldType != newType {
+		d.add(true, operation, location, "type changed from %q to %q", oldType, newType)
+		return
+	}
+	oldFormat, _ := oldSchema["format"].(string)
+	newFormat, _ := newSchema["format"].(string)
+	if oldFormat != newFormat {
+		d.add(true, operation, location, "format changed from %q to %q", oldFormat, newFormat)
+	}
+
+	// enum
+	oldEnum := asSlice(oldSchema["enum"])
+	newEnum := asSlice(newSchema["enum"])
+	removed := []string{}
+	added := []string{}
+	if len(newEnum) > 0 {
+		for _, e := range oldEnum {
+			if !containsValue(newEnum, e) {
+				removed = append(removed, fmt.Sprint(e))
+			}
+		}
+	}
+	if len(oldEnum) > 0 {
+		for _, e := range newEnum {
+			if !containsValue(oldEnum, e) {
+				added = append(added, fmt.Sprint(e))
+			}
+		}
+	}
+	if len(oldEnum) == 0 && len(newEnum) > 0 {
+		d.add(direction == diffRequest, operation, location, "enum %v introduced", newEnum)
+	}
+	if len(removed) > 0 {
+		d.add(direction == diffRequest, operation, location, "enum values %v removed", removed)
+	}
+	if len(added) > 0 {
+		d.add(false, operation, location, "enum values %v added", added)
+	}
+
+	// properties
+	oldProperties := asMap(oldSchema["properties"])
+	newProperties := asMap(newSchema["properties"])
+	oldRequired := asSlice(oldSchema["required"])
+	newRequired := asSlice(newSchema["required"])
+	for _, name := range unionKeys(oldProperties, newProperties) {
+		propertyLocation := location + "." + name
+		oldProperty, inOld := oldProperties[name]
+		newProperty, inNew := newProperties[name]
+		switch {
+		case !inNew:
+			d.add(direction == diffResponse, operation, propertyLocation, "property removed")
+		case !inOld:
+			required := containsValue(newRequired, name)
+			d.add(direction == diffRequest && required, operation, propertyLocation, "property added")
+		default:
+			if direction == diffRequest && containsValue(newRequired, name) && !containsValue(oldRequired, name) {
+				d.add(true, operation, propertyLocation, "property became required")
+			}
+			if direction == diffResponse && !containsValue(newRequired, name) && containsValue(oldRequired, name) {
+				d.add(true, operation, propertyLocation, "property became optional")
+			}
+			d.diffSchema(operation, propertyLocation, oldProperty, newProperty, direction)
+		}
+	}
+	if oldType == "array" {
+		d.diffSchema(operation, location+"[]", oldSchema["items"], newSchema["items"], direction)
+	}
+	d.diffComposedSchemas(operation, location, oldSchema, newSchema, direction)
+}
+
+// diffComposedSchemas compares the subschemas of allOf, oneOf and anyOf.
+// The subschemas are compared by the index if their numbers are the same.
+// Otherwise, the added subschemas of allOf narrow the accepted values, and the added ones of oneOf and anyOf widen them,
+// so the change is breaking if the consumers can be given the values they do not expect.
+func (d *definitionDiff) diffComposedSchemas(operation, location string, oldSchema, newSchema map[string]interface{}, direction diffDirection) {
+	for _, keyword := range []string{"allOf", "oneOf", "anyOf"} {
+		oldSchemas := asSlice(oldSchema[keyword])
+		newSchemas := asSlice(newSchema[keyword])
+		switch {
+		case len(oldSchemas) == len(newSchemas):
+			for i := range oldSchemas {
+				d.diffSchema(operation, fmt.Sprintf("%s.%s[%d]", location, keyword, i), oldSchemas[i], newSchemas[i], direction)
+			}
+		case len(oldSchemas) < len(newSchemas):
+			narrowed := keyword == "allOf"
+			d.add(narrowed == (direction == diffRequest), operation, location, "%s subschemas added", keyword)
+		default:
+			narrowed := keyword != "allOf"
+			d.add(narrowed == (direction == diffRequest), operation, location, "%s subschemas removed", keyword)
+		}
+	}
+}
+
+// resolve resolves the local reference.
+func (d *definitionDiff) resolve(doc map[string]interface{}, v interface{}) map[string]interface{} {
+	m := asMap(v)
+	for i := 0; i < 10; i++ {
+		ref, ok := m["$ref"].(string)
+		if !ok {
+			break
+		}
+		resolved, ok := resolveJSONPointer(doc, ref)
+		if !ok {
+			break
+		}
+		m = asMap(resolved)
+	}
+	return m
+}
+
+func unionKeys(a, b map[string]interface{}) []string {
+	keys := sortedKeys(a)
+	for _, k := range sortedKeys(b) {
+		if _, ok := a[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func unionParameterKeys(a, b map[string]map[string]interface{}) []string {
+	keys := sortedParameterKeys(a)
+	for _, k := range sortedParameterKeys(b) {
+		if _, ok := a[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
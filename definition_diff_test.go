@@ -0,0 +1,92 @@
+package wso2am
+
+import (
+	"reflect"
+	"testing"
+)
+
+// diffTestDefinition returns the OpenAPI 3 definition of POST /pets with the request and the response schemas.
+func diffTestDefinition(request, response string) APIDefinition {
+	return APIDefinition(`{
+  "openapi": "3.0.0",
+  "info": {"title": "pets", "version": "1.0.0"},
+  "paths": {
+    "/pets": {
+      "post": {
+        "requestBody": {"content": {"application/json": {"schema": ` + request + `}}},
+        "responses": {"200": {"description": "OK", "content": {"application/json": {"schema": ` + response + `}}}}
+      }
+    }
+  },
+  "components": {
+    "schemas": {
+      "Pet": {
+        "type": "object",
+        "properties": {"name": {"type": "string"}, "parent": {"$ref": "#/components/schemas/Pet"}}
+      }
+    }
+  }
+}`)
+}
+
+func TestDiffAPIDefinitions(t *testing.T) {
+	const object = `{"type": "object"}`
+	tests := []struct {
+		name           string
+		oldReq, oldRes string
+		newReq, newRes string
+		changes        []string
+	}{
+		{
+			name:   "no change with the recursive schema",
+			oldReq: `{"$ref": "#/components/schemas/Pet"}`, oldRes: object,
+			newReq: `{"$ref": "#/components/schemas/Pet"}`, newRes: object,
+			changes: []string{},
+		},
+		{
+			name:   "required property added to the request",
+			oldReq: `{"type": "object", "properties": {"a": {"type": "string"}}}`, oldRes: object,
+			newReq: `{"type": "object", "properties": {"a": {"type": "string"}, "b": {"type": "string"}}, "required": ["b"]}`, newRes: object,
+			changes: []string{"[breaking] POST /pets request body application/json.b: property added"},
+		},
+		{
+			name:   "property type changed in the composed request schema",
+			oldReq: `{"allOf": [{"$ref": "#/components/schemas/Pet"}, {"type": "object", "properties": {"age": {"type": "integer"}}}]}`, oldRes: object,
+			newReq: `{"allOf": [{"$ref": "#/components/schemas/Pet"}, {"type": "object", "properties": {"age": {"type": "string"}}}]}`, newRes: object,
+			changes: []string{`[breaking] POST /pets request body application/json.allOf[1].age: type changed from "integer" to "string"`},
+		},
+		{
+			name:   "allOf subschema added to the request",
+			oldReq: `{"allOf": [{"$ref": "#/components/schemas/Pet"}]}`, oldRes: object,
+			newReq: `{"allOf": [{"$ref": "#/components/schemas/Pet"}, {"required": ["name"]}]}`, newRes: object,
+			changes: []string{"[breaking] POST /pets request body application/json: allOf subschemas added"},
+		},
+		{
+			name:   "oneOf subschema added to the request",
+			oldReq: `{"oneOf": [{"type": "string"}]}`, oldRes: object,
+			newReq: `{"oneOf": [{"type": "string"}, {"type": "integer"}]}`, newRes: object,
+			changes: []string{"[non-breaking] POST /pets request body application/json: oneOf subschemas added"},
+		},
+		{
+			name:   "anyOf subschema added to the response",
+			oldReq: object, oldRes: `{"anyOf": [{"type": "string"}]}`,
+			newReq: object, newRes: `{"anyOf": [{"type": "string"}, {"type": "integer"}]}`,
+			changes: []string{"[breaking] POST /pets response 200 application/json: anyOf subschemas added"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			changes, err := DiffAPIDefinitions(diffTestDefinition(tt.oldReq, tt.oldRes), diffTestDefinition(tt.newReq, tt.newRes))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			got := []string{}
+			for _, c := range changes {
+				got = append(got, c.String())
+			}
+			if !reflect.DeepEqual(got, tt.changes) {
+				t.Errorf("changes = %q, want %q", got, tt.changes)
+			}
+		})
+	}
+}
@@ -0,0 +1,289 @@
+package wso2am
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+type (
+	// APIResource is an operation of the API with the WSO2 extensions.
+	APIResource struct {
+		Verb            string `json:"verb"`
+		Path            string `json:"path"`
+		AuthType        string `json:"authType,omitempty"`
+		ThrottlingTier  string `json:"throttlingTier,omitempty"`
+		Scope           string `json:"scope,omitempty"`
+		MediationScript string `json:"mediationScript,omitempty"`
+	}
+	// APIScope is a scope defined in x-wso2-security of the API definition.
+	APIScope struct {
+		Key         string `json:"key"`
+		Name        string `json:"name"`
+		Description string `json:"description"`
+		// Roles is the comma separated role names.
+		Roles string `json:"roles"`
+	}
+)
+
+const (
+	extensionAuthType        = "x-auth-type"
+	extensionThrottlingTier  = "x-throttling-tier"
+	extensionScope           = "x-scope"
+	extensionMediationScript = "x-mediation-script"
+)
+
+// Resources returns the resources of the API definition.
+func (d APIDefinition) Resources() ([]APIResource, error) {
+	doc, err := d.parse()
+	if err != nil {
+		return nil, err
+	}
+	resources := []APIResource{}
+	paths := asMap(doc["paths"])
+	for _, p := range sortedKeys(paths) {
+		item := asMap(paths[p])
+		for _, method := range swagger2Operations {
+			op, ok := item[method].(map[string]interface{})
+			if !ok {
+				continue
+			}
+			r := APIResource{
+				Verb: strings.ToUpper(method),
+				Path: p,
+			}
+			r.AuthType, _ = op[extensionAuthType].(string)
+			r.ThrottlingTier, _ = op[extensionThrottlingTier].(string)
+			r.Scope, _ = op[extensionScope].(string)
+			r.MediationScript, _ = op[extensionMediationScript].(string)
+			resources = append(resources, r)
+		}
+	}
+	return resources, nil
+}
+
+// Resource returns the resource of the verb and path.
+func (d APIDefinition) Resource(verb, path string) (*APIResource, error) {
+	resources, err := d.Resources()
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range resources {
+		if strings.EqualFold(r.Verb, verb) && r.Path == path {
+			return &r, nil
+		}
+	}
+	return nil, fmt.Errorf("resource not found: %s %s", strings.ToUpper(verb), path)
+}
+
+// SetResource writes the WSO2 extensions of the resource to the operation of the verb and path.
+// If the operation does not exist, it is created with the default response.
+// The empty fields of the resource are removed from the operation.
+func (d APIDefinition) SetResource(resource APIResource) (APIDefinition, error) {
+	doc, err := d.parse()
+	if err != nil {
+		return "", err
+	}
+	method := strings.ToLower(resource.Verb)
+	if !containsString(swagger2Operations, method) {
+		return "", fmt.Errorf("unsupported verb: %s", resource.Verb)
+	}
+	if !strings.HasPrefix(resource.Path, "/") {
+		return "", fmt.Errorf("path must start with '/': %s", resource.Path)
+	}
+	if resource.AuthType != "" && !containsString(APIAuthTypes, resource.AuthType) {
+		return "", fmt.Errorf("invalid auth type %s (available: %s)", resource.AuthType, strings.Join(APIAuthTypes, ", "))
+	}
+	if resource.Scope != "" {
+		if _, ok := findScope(doc, resource.Scope); !ok {
+			return "", fmt.Errorf("scope %s is not defined; add the scope first", resource.Scope)
+		}
+	}
+
+	if _, ok := doc["paths"].(map[string]interface{}); !ok {
+		doc["paths"] = map[string]interface{}{}
+	}
+	paths := doc["paths"].(map[string]interface{})
+	if _, ok := paths[resource.Path].(map[string]interface{}); !ok {
+		paths[resource.Path] = map[string]interface{}{}
+	}
+	item := paths[resource.Path].(map[string]interface{})
+	op, ok := item[method].(map[string]interface{})
+	if !ok {
+		op = map[string]interface{}{
+			"responses": map[string]interface{}{
+				"200": map[string]interface{}{"description": ""},
+			},
+		}
+		if params := pathTemplatePattern.FindAllStringSubmatch(resource.Path, -1); len(params) > 0 {
+			op["parameters"] = newPathParameters(doc, params)
+		}
+		item[method] = op
+	}
+	for k, v := range map[string]string{
+		extensionAuthType:        resource.AuthType,
+		extensionThrottlingTier:  resource.ThrottlingTier,
+		extensionScope:           resource.Scope,
+		extensionMediationScript: resource.MediationScript,
+	} {
+		if v == "" {
+			delete(op, k)
+		} else {
+			op[k] = v
+		}
+	}
+	return newAPIDefinition(doc)
+}
+
+func newPathParameters(doc map[string]interface{}, params [][]string) []interface{} {
+	l := []interface{}{}
+	for _, p := range params {
+		param := map[string]interface{}{
+			"name":     p[1],
+			"in":       "path",
+			"required": true,
+		}
+		if spec, _ := detectAPIDefinitionSpec(doc); spec == APIDefinitionSpecOpenAPI3 {
+			param["schema"] = map[string]interface{}{"type": "string"}
+		} else {
+			param["type"] = "string"
+		}
+		l = append(l, param)
+	}
+	return l
+}
+
+// RemoveResource removes the operation of the verb and path.
+func (d APIDefinition) RemoveResource(verb, path string) (APIDefinition, error) {
+	doc, err := d.parse()
+	if err != nil {
+		return "", err
+	}
+	paths := asMap(doc["paths"])
+	item, ok := paths[path].(map[string]interface{})
+	method := strings.ToLower(verb)
+	if !ok || item[method] == nil {
+		return "", fmt.Errorf("resource not found: %s %s", strings.ToUpper(verb), path)
+	}
+	delete(item, method)
+	operations := 0
+	for _, m := range swagger2Operations {
+		if _, ok := item[m]; ok {
+			operations++
+		}
+	}
+	if operations == 0 {
+		delete(paths, path)
+	}
+	return newAPIDefinition(doc)
+}
+
+// Scopes returns the scopes defined in x-wso2-security.
+func (d APIDefinition) Scopes() ([]APIScope, error) {
+	doc, err := d.parse()
+	if err != nil {
+		return nil, err
+	}
+	scopes := []APIScope{}
+	for _, s := range wso2Scopes(doc) {
+		var scope APIScope
+		if err := convert(s, &scope); err != nil {
+			return nil, err
+		}
+		scopes = append(scopes, scope)
+	}
+	return scopes, nil
+}
+
+// SetScope adds the scope to x-wso2-security or replaces the scope which has the same key.
+func (d APIDefinition) SetScope(scope APIScope) (APIDefinition, error) {
+	if scope.Key == "" {
+		return "", errors.New("scope key is required")
+	}
+	if scope.Name == "" {
+		scope.Name = scope.Key
+	}
+	doc, err := d.parse()
+	if err != nil {
+		return "", err
+	}
+	var v map[string]interface{}
+	if err := convert(scope, &v); err != nil {
+		return "", err
+	}
+	scopes := wso2Scopes(doc)
+	if i, ok := findScope(doc, scope.Key); ok {
+		scopes[i] = v
+	} else {
+		scopes = append(scopes, v)
+	}
+	if _, ok := doc["x-wso2-security"].(map[string]interface{}); !ok {
+		doc["x-wso2-security"] = map[string]interface{}{}
+	}
+	security := doc["x-wso2-security"].(map[string]interface{})
+	if _, ok := security["apim"].(map[string]interface{}); !ok {
+		security["apim"] = map[string]interface{}{}
+	}
+	security["apim"].(map[string]interface{})["x-wso2-scopes"] = scopes
+	return newAPIDefinition(doc)
+}
+
+func wso2Scopes(doc map[string]interface{}) []interface{} {
+	return asSlice(asMap(asMap(doc["x-wso2-security"])["apim"])["x-wso2-scopes"])
+}
+
+func findScope(doc map[string]interface{}, key string) (int, bool) {
+	for i, s := range wso2Scopes(doc) {
+		if asMap(s)["key"] == key {
+			return i, true
+		}
+	}
+	return -1, false
+}
+
+// APIResources returns the resources of the API.
+func (c *Client) APIResources(id string) ([]APIResource, error) {
+	def, err := c.currentAPIDefinition(id)
+	if err != nil {
+		return nil, err
+	}
+	return def.Resources()
+}
+
+// UpdateAPIResource updates the WSO2 extensions of the resource in the API definition.
+func (c *Client) UpdateAPIResource(id string, resource APIResource) (*APIResource, error) {
+	def, err := c.currentAPIDefinition(id)
+	if err != nil {
+		return nil, err
+	}
+	def, err = def.SetResource(resource)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := c.UpdateAPIDefinition(id, def); err != nil {
+		return nil, err
+	}
+	return &resource, nil
+}
+
+// AddAPIScope adds the scope to the API definition or replaces the scope which has the same key.
+func (c *Client) AddAPIScope(id string, scope APIScope) error {
+	def, err := c.currentAPIDefinition(id)
+	if err != nil {
+		return err
+	}
+	def, err = def.SetScope(scope)
+	if err != nil {
+		return err
+	}
+	_, err = c.UpdateAPIDefinition(id, def)
+	return err
+}
+
+func (c *Client) currentAPIDefinition(id string) (APIDefinition, error) {
+	v, err := c.APIDefinition(id)
+	if err != nil {
+		return "", err
+	}
+	return newAPIDefinition(v)
+}
@@ -0,0 +1,576 @@
+package wso2am
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+type (
+	ValidationSeverity string
+	// ValidationIssue is a problem found in an API definition.
+	ValidationIssue struct {
+		Severity ValidationSeverity `json:"severity"`
+		// Path is the JSON pointer to the location of the problem.
+		Path    string `json:"path"`
+		Message string `json:"message"`
+	}
+	// ValidationError is returned by APIDefinition.Validate if the definition has errors.
+	ValidationError struct {
+		Issues []ValidationIssue
+	}
+	definitionValidator struct {
+		root   map[string]interface{}
+		spec   APIDefinitionSpec
+		issues []ValidationIssue
+		// scopes is the scopes defined in x-wso2-security by the key.
+		scopes map[string]bool
+		// usedScopes is the scopes referred by x-scope.
+		usedScopes    map[string]bool
+		operationIDs  map[string]string
+		securityTypes map[string]string
+	}
+)
+
+const (
+	ValidationSeverityError   ValidationSeverity = "error"
+	ValidationSeverityWarning ValidationSeverity = "warning"
+)
+
+var (
+	// APIAuthTypes are the values of x-auth-type which API Manager accepts.
+	APIAuthTypes = []string{
+		"Any",
+		"None",
+		"Application",
+		"Application User",
+		"Application & Application User",
+	}
+	swagger2ParameterLocations = []string{"query", "header", "path", "formData", "body"}
+	openAPI3ParameterLocations = []string{"query", "header", "path", "cookie"}
+	swagger2ParameterTypes     = []string{"string", "number", "integer", "boolean", "array", "file"}
+	schemaTypes                = []string{"string", "number", "integer", "boolean", "array", "object", "null"}
+	definitionSchemes          = []string{"http", "https", "ws", "wss"}
+	responseCodePattern        = regexp.MustCompile(`^([1-5][0-9][0-9]|[1-5]XX|default)$`)
+	pathTemplatePattern        = regexp.MustCompile(`{([^}]+)}`)
+)
+
+func (e *ValidationError) Error() string {
+	messages := []string{}
+	for _, issue := range e.Issues {
+		if issue.Severity == ValidationSeverityError {
+			messages = append(messages, fmt.Sprintf("%s: %s", issue.Path, issue.Message))
+		}
+	}
+	return fmt.Sprintf("invalid API definition: %s", strings.Join(messages, ", "))
+}
+
+func (i ValidationIssue) String() string {
+	return fmt.Sprintf("%s: %s: %s", i.Severity, i.Path, i.Message)
+}
+
+// Validate validates the API definition and returns *ValidationError if it has errors.
+func (d APIDefinition) Validate() error {
+	issues, err := d.Lint()
+	if err != nil {
+		return err
+	}
+	for _, issue := range issues {
+		if issue.Severity == ValidationSeverityError {
+			return &ValidationError{issues}
+		}
+	}
+	return nil
+}
+
+// Lint checks the API definition conforms to the specification and the WSO2 extensions
+// (x-auth-type, x-throttling-tier, x-scope and x-wso2-security) are valid.
+func (d APIDefinition) Lint() ([]ValidationIssue, error) {
+	doc, err := d.parse()
+	if err != nil {
+		return nil, err
+	}
+	v := &definitionValidator{
+		root:          doc,
+		issues:        []ValidationIssue{},
+		scopes:        map[string]bool{},
+		usedScopes:    map[string]bool{},
+		operationIDs:  map[string]string{},
+		securityTypes: map[string]string{},
+	}
+	v.spec, err = detectAPIDefinitionSpec(doc)
+	if err != nil {
+		v.error("", "%v", err)
+		return v.issues, nil
+	}
+	v.validate()
+	return v.issues, nil
+}
+
+func (v *definitionValidator) error(path string, format string, args ...interface{}) {
+	v.issues = append(v.issues, ValidationIssue{ValidationSeverityError, path, fmt.Sprintf(format, args...)})
+}
+
+func (v *definitionValidator) warn(path string, format string, args ...interface{}) {
+	v.issues = append(v.issues, ValidationIssue{ValidationSeverityWarning, path, fmt.Sprintf(format, args...)})
+}
+
+func (v *definitionValidator) validate() {
+	info, ok := v.root["info"].(map[string]interface{})
+	if !ok {
+		v.error("/info", "info is required")
+	} else {
+		for _, k := range []string{"title", "version"} {
+			if s, ok := info[k].(string); !ok || s == "" {
+				v.error("/info/"+k, "%s is required", k)
+			}
+		}
+	}
+
+	if v.spec == APIDefinitionSpecSwagger2 {
+		if basePath, ok := v.root["basePath"].(string); ok && !strings.HasPrefix(basePath, "/") {
+			v.error("/basePath", "basePath must start with '/'")
+		}
+		for i, scheme := range asSlice(v.root["schemes"]) {
+			if !containsString(definitionSchemes, fmt.Sprint(scheme)) {
+				v.error(fmt.Sprintf("/schemes/%d", i), "invalid scheme %v", scheme)
+			}
+		}
+		v.validateSecuritySchemes("/securityDefinitions", asMap(v.root["securityDefinitions"]))
+		for _, name := range sortedKeys(asMap(v.root["definitions"])) {
+			v.validateSchema("/definitions/"+escapeJSONPointer(name), asMap(v.root["definitions"])[name])
+		}
+	} else {
+		for i, server := range asSlice(v.root["servers"]) {
+			if _, ok := asMap(server)["url"].(string); !ok {
+				v.error(fmt.Sprintf("/servers/%d/url", i), "url is required")
+			}
+		}
+		components := asMap(v.root["components"])
+		v.validateSecuritySchemes("/components/securitySchemes", asMap(components["securitySchemes"]))
+		for _, name := range sortedKeys(asMap(components["schemas"])) {
+			v.validateSchema("/components/schemas/"+escapeJSONPointer(name), asMap(components["schemas"])[name])
+		}
+	}
+	v.validateSecurityRequirements("/security", v.root["security"])
+	v.validateWSO2Security()
+
+	paths, ok := v.root["paths"].(map[string]interface{})
+	if !ok {
+		v.error("/paths", "paths is required")
+	}
+	for _, p := range sortedKeys(paths) {
+		v.validatePathItem(p, asMap(paths[p]))
+	}
+
+	for _, key := range sortedStringSet(v.scopes) {
+		if !v.usedScopes[key] {
+			v.warn("/x-wso2-security", "scope %s is not used by any resource", key)
+		}
+	}
+	v.validateRefs("", v.root)
+}
+
+func (v *definitionValidator) validatePathItem(p string, item map[string]interface{}) {
+	path := "/paths/" + escapeJSONPointer(p)
+	if !strings.HasPrefix(p, "/") {
+		v.error(path, "path must start with '/'")
+	}
+	pathParams := v.resolveParameters(asSlice(item["parameters"]))
+	v.validateParameters(path+"/parameters", asSlice(item["parameters"]))
+	methods := swagger2Operations
+	if v.spec == APIDefinitionSpecOpenAPI3 {
+		methods = append(append([]string{}, swagger2Operations...), "trace")
+	}
+	for _, method := range methods {
+		op, ok := item[method].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		opPath := path + "/" + method
+		v.validateOperation(opPath, op)
+
+		// the path template parameters must be declared
+		params := map[string]map[string]interface{}{}
+		for k, p := range pathParams {
+			params[k] = p
+		}
+		for k, p := range v.resolveParameters(asSlice(op["parameters"])) {
+			params[k] = p
+		}
+		for _, m := range pathTemplatePattern.FindAllStringSubmatch(p, -1) {
+			if _, ok := params["path:"+m[1]]; !ok {
+				v.error(opPath, "path parameter %s is not declared", m[1])
+			}
+		}
+		for _, k := range sortedParameterKeys(params) {
+			param := params[k]
+			if strings.HasPrefix(k, "path:") && !strings.Contains(p, "{"+fmt.Sprint(param["name"])+"}") {
+				v.error(opPath, "path parameter %v is declared but not in the path", param["name"])
+			}
+		}
+	}
+}
+
+func (v *definitionValidator) validateOperation(path string, op map[string]interface{}) {
+	if id, ok := op["operationId"].(string); ok {
+		if other, ok := v.operationIDs[id]; ok {
+			v.error(path+"/operationId", "duplicate operationId %s (also used by %s)", id, other)
+		}
+		v.operationIDs[id] = path
+	}
+	v.validateParameters(path+"/parameters", asSlice(op["parameters"]))
+	if v.spec == APIDefinitionSpecSwagger2 {
+		var body, form bool
+		for _, p := range v.resolveParameters(asSlice(op["parameters"])) {
+			switch p["in"] {
+			case "body":
+				body = true
+			case "formData":
+				form = true
+				if p["type"] == "file" {
+					consumes := asSlice(v.root["consumes"])
+					if c, ok := op["consumes"]; ok {
+						consumes = asSlice(c)
+					}
+					if !containsValue(consumes, "multipart/form-data") && !containsValue(consumes, "application/x-www-form-urlencoded") {
+						v.error(path+"/consumes", "file parameters require multipart/form-data or application/x-www-form-urlencoded")
+					}
+				}
+			}
+		}
+		if body && form {
+			v.error(path+"/parameters", "body and formData parameters cannot be used together")
+		}
+	} else if body, ok := op["requestBody"]; ok {
+		b := asMap(body)
+		if ref, ok := b["$ref"].(string); ok {
+			b = v.resolve(ref)
+		}
+		if len(asMap(b["content"])) == 0 {
+			v.error(path+"/requestBody/content", "content is required")
+		}
+		for _, mediaType := range sortedKeys(asMap(b["content"])) {
+			v.validateSchema(path+"/requestBody/content/"+escapeJSONPointer(mediaType)+"/schema", asMap(asMap(b["content"])[mediaType])["schema"])
+		}
+	}
+
+	responses, ok := op["responses"].(map[string]interface{})
+	if !ok || len(responses) == 0 {
+		v.error(path+"/responses", "responses is required")
+	}
+	for _, code := range sortedKeys(responses) {
+		rpath := path + "/responses/" + code
+		if !responseCodePattern.MatchString(code) && !strings.HasPrefix(code, "x-") {
+			v.error(rpath, "invalid response code %s", code)
+			continue
+		}
+		r := asMap(responses[code])
+		if _, ok := r["$ref"]; ok {
+			continue
+		}
+		if _, ok := r["description"].(string); !ok {
+			v.error(rpath+"/description", "description is required")
+		}
+		if v.spec == APIDefinitionSpecSwagger2 {
+			if schema, ok := r["schema"]; ok {
+				v.validateSchema(rpath+"/schema", schema)
+			}
+		} else {
+			for _, mediaType := range sortedKeys(asMap(r["content"])) {
+				v.validateSchema(rpath+"/content/"+escapeJSONPointer(mediaType)+"/schema", asMap(asMap(r["content"])[mediaType])["schema"])
+			}
+		}
+	}
+	v.validateSecurityRequirements(path+"/security", op["security"])
+
+	// WSO2 extensions
+	if authType, ok := op["x-auth-type"]; ok {
+		if s, ok := authType.(string); !ok || !containsString(APIAuthTypes, s) {
+			v.error(path+"/x-auth-type", "invalid auth type %v (available: %s)", authType, strings.Join(APIAuthTypes, ", "))
+		}
+	}
+	if tier, ok := op["x-throttling-tier"]; ok {
+		if s, ok := tier.(string); !ok || s == "" {
+			v.error(path+"/x-throttling-tier", "throttling tier must be a non-empty string")
+		}
+	}
+	if scope, ok := op["x-scope"]; ok {
+		s, ok := scope.(string)
+		switch {
+		case !ok || s == "":
+			v.error(path+"/x-scope", "scope must be a non-empty string")
+		case !v.scopes[s]:
+			v.error(path+"/x-scope", "scope %s is not defined in x-wso2-security", s)
+		default:
+			v.usedScopes[s] = true
+		}
+	}
+	if script, ok := op["x-mediation-script"]; ok {
+		if _, ok := script.(string); !ok {
+			v.error(path+"/x-mediation-script", "mediation script must be a string")
+		}
+	}
+}
+
+// resolveParameters returns the parameters by "IN:NAME".
+func (v *definitionValidator) resolveParameters(params []interface{}) map[string]map[string]interface{} {
+	m := map[string]map[string]interface{}{}
+	for _, p := range params {
+		param := asMap(p)
+		if ref, ok := param["$ref"].(string); ok {
+			param = v.resolve(ref)
+		}
+		m[fmt.Sprintf("%v:%v", param["in"], param["name"])] = param
+	}
+	return m
+}
+
+func (v *definitionValidator) validateParameters(path string, params []interface{}) {
+	seen := map[string]bool{}
+	bodies := 0
+	for i, p := range params {
+		ppath := fmt.Sprintf("%s/%d", path, i)
+		param := asMap(p)
+		if ref, ok := param["$ref"].(string); ok {
+			param = v.resolve(ref)
+			if param == nil {
+				continue
+			}
+		}
+		name, ok := param["name"].(string)
+		if !ok || name == "" {
+			v.error(ppath+"/name", "name is required")
+		}
+		in, _ := param["in"].(string)
+		locations := swagger2ParameterLocations
+		if v.spec == APIDefinitionSpecOpenAPI3 {
+			locations = openAPI3ParameterLocations
+		}
+		if !containsString(locations, in) {
+			v.error(ppath+"/in", "invalid parameter location %v", param["in"])
+			continue
+		}
+		key := in + ":" + name
+		if seen[key] {
+			v.error(ppath, "duplicate parameter %s in %s", name, in)
+		}
+		seen[key] = true
+		if in == "path" {
+			if required, _ := param["required"].(bool); !required {
+				v.error(ppath+"/required", "path parameters must be required")
+			}
+		}
+
+		if v.spec == APIDefinitionSpecOpenAPI3 {
+			_, hasSchema := param["schema"]
+			_, hasContent := param["content"]
+			if hasSchema == hasContent {
+				v.error(ppath, "either schema or content is required")
+			}
+			if hasSchema {
+				v.validateSchema(ppath+"/schema", param["schema"])
+			}
+			continue
+		}
+		if in == "body" {
+			bodies++
+			if schema, ok := param["schema"]; ok {
+				v.validateSchema(ppath+"/schema", schema)
+			} else {
+				v.error(ppath+"/schema", "schema is required for body parameters")
+			}
+			continue
+		}
+		t, _ := param["type"].(string)
+		switch {
+		case !containsString(swagger2ParameterTypes, t):
+			v.error(ppath+"/type", "invalid parameter type %v", param["type"])
+		case t == "array" && param["items"] == nil:
+			v.error(ppath+"/items", "items is required for array parameters")
+		case t == "file" && in != "formData":
+			v.error(ppath+"/type", "file parameters must be in formData")
+		}
+	}
+	if bodies > 1 {
+		v.error(path, "only one body parameter is allowed")
+	}
+}
+
+func (v *definitionValidator) validateSchema(path string, s interface{}) {
+	schema, ok := s.(map[string]interface{})
+	if !ok {
+		v.error(path, "schema must be an object")
+		return
+	}
+	if _, ok := schema["$ref"]; ok {
+		return
+	}
+	if t, ok := schema["type"]; ok {
+		if !containsString(schemaTypes, fmt.Sprint(t)) && !(v.spec == APIDefinitionSpecSwagger2 && t == "file") {
+			v.error(path+"/type", "invalid schema type %v", t)
+		}
+		if t == "array" && schema["items"] == nil {
+			v.error(path+"/items", "items is required for array schemas")
+		}
+	}
+	for _, name := range sortedKeys(asMap(schema["properties"])) {
+		v.validateSchema(path+"/properties/"+escapeJSONPointer(name), asMap(schema["properties"])[name])
+	}
+	if items, ok := schema["items"].(map[string]interface{}); ok {
+		v.validateSchema(path+"/items", items)
+	}
+	for _, r := range asSlice(schema["required"]) {
+		if _, ok := asMap(schema["properties"])[fmt.Sprint(r)]; !ok && schema["properties"] != nil {
+			v.warn(path+"/required", "required property %v is not defined", r)
+		}
+	}
+}
+
+func (v *definitionValidator) validateSecuritySchemes(path string, schemes map[string]interface{}) {
+	for _, name := range sortedKeys(schemes) {
+		scheme := asMap(schemes[name])
+		spath := path + "/" + escapeJSONPointer(name)
+		t, _ := scheme["type"].(string)
+		v.securityTypes[name] = t
+		var types []string
+		if v.spec == APIDefinitionSpecSwagger2 {
+			types = []string{"basic", "apiKey", "oauth2"}
+		} else {
+			types = []string{"http", "apiKey", "oauth2", "openIdConnect"}
+		}
+		if !containsString(types, t) {
+			v.error(spath+"/type", "invalid security scheme type %v", scheme["type"])
+			continue
+		}
+		if t == "apiKey" {
+			if _, ok := scheme["name"].(string); !ok {
+				v.error(spath+"/name", "name is required")
+			}
+			if _, ok := scheme["in"].(string); !ok {
+				v.error(spath+"/in", "in is required")
+			}
+		}
+	}
+}
+
+func (v *definitionValidator) validateSecurityRequirements(path string, requirements interface{}) {
+	for i, r := range asSlice(requirements) {
+		for _, name := range sortedKeys(asMap(r)) {
+			if _, ok := v.securityTypes[name]; !ok {
+				v.error(fmt.Sprintf("%s/%d/%s", path, i, escapeJSONPointer(name)), "security scheme %s is not defined", name)
+			}
+		}
+	}
+}
+
+// validateWSO2Security validates the scopes defined in x-wso2-security.
+//
+//	x-wso2-security:
+//	  apim:
+//	    x-wso2-scopes:
+//	      - name: read
+//	        description: read
+//	        key: read
+//	        roles: admin,user
+func (v *definitionValidator) validateWSO2Security() {
+	security, ok := v.root["x-wso2-security"]
+	if !ok {
+		return
+	}
+	apim, ok := asMap(security)["apim"].(map[string]interface{})
+	if !ok {
+		v.error("/x-wso2-security/apim", "apim is required")
+		return
+	}
+	scopes, ok := apim["x-wso2-scopes"].([]interface{})
+	if !ok {
+		if _, exists := apim["x-wso2-scopes"]; exists {
+			v.error("/x-wso2-security/apim/x-wso2-scopes", "scopes must be a list")
+		}
+		return
+	}
+	for i, s := range scopes {
+		path := fmt.Sprintf("/x-wso2-security/apim/x-wso2-scopes/%d", i)
+		scope := asMap(s)
+		key, ok := scope["key"].(string)
+		if !ok || key == "" {
+			v.error(path+"/key", "key is required")
+			continue
+		}
+		if v.scopes[key] {
+			v.error(path+"/key", "duplicate scope key %s", key)
+		}
+		v.scopes[key] = true
+		if name, ok := scope["name"].(string); !ok || name == "" {
+			v.error(path+"/name", "name is required")
+		}
+		switch roles := scope["roles"].(type) {
+		case nil:
+			v.warn(path+"/roles", "no roles are assigned to the scope %s", key)
+		case string:
+			if strings.TrimSpace(roles) == "" {
+				v.warn(path+"/roles", "no roles are assigned to the scope %s", key)
+			}
+			for _, role := range strings.Split(roles, ",") {
+				if strings.TrimSpace(role) == "" && strings.TrimSpace(roles) != "" {
+					v.error(path+"/roles", "empty role name in %q", roles)
+				}
+			}
+		default:
+			v.error(path+"/roles", "roles must be a comma separated string")
+		}
+	}
+}
+
+// validateRefs checks the local references can be resolved.
+func (v *definitionValidator) validateRefs(path string, value interface{}) {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		for _, k := range sortedKeys(value) {
+			if ref, ok := value[k].(string); ok && k == "$ref" {
+				if !strings.HasPrefix(ref, "#") {
+					v.error(path+"/$ref", "external reference %s is not supported; bundle the definition first", ref)
+				} else if _, ok := resolveJSONPointer(v.root, ref); !ok {
+					v.error(path+"/$ref", "cannot resolve the reference %s", ref)
+				}
+				continue
+			}
+			if k == "example" || k == "examples" {
+				continue
+			}
+			v.validateRefs(path+"/"+escapeJSONPointer(k), value[k])
+		}
+	case []interface{}:
+		for i, e := range value {
+			v.validateRefs(fmt.Sprintf("%s/%d", path, i), e)
+		}
+	}
+}
+
+func (v *definitionValidator) resolve(ref string) map[string]interface{} {
+	resolved, ok := resolveJSONPointer(v.root, ref)
+	if !ok {
+		return nil
+	}
+	return asMap(resolved)
+}
+
+func sortedStringSet(m map[string]bool) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func sortedParameterKeys(m map[string]map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,125 @@
+package wso2am
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestAPIDefinitionLint(t *testing.T) {
+	tests := []struct {
+		name   string
+		def    string
+		issues []string
+	}{
+		{
+			name: "valid Swagger 2.0",
+			def: `{
+  "swagger": "2.0",
+  "info": {"title": "pets", "version": "1.0.0"},
+  "basePath": "/pets",
+  "paths": {
+    "/pets/{id}": {
+      "parameters": [{"name": "id", "in": "path", "required": true, "type": "string"}],
+      "get": {"x-auth-type": "Application & Application User", "responses": {"200": {"description": "OK"}}}
+    }
+  }
+}`,
+			issues: []string{},
+		},
+		{
+			name: "invalid Swagger 2.0",
+			def: `{
+  "swagger": "2.0",
+  "info": {"title": "pets"},
+  "basePath": "pets",
+  "paths": {
+    "/pets/{id}": {
+      "get": {
+        "operationId": "getPet",
+        "parameters": [
+          {"name": "pet", "in": "body", "schema": {"type": "object"}},
+          {"name": "photo", "in": "formData", "type": "file"}
+        ],
+        "responses": {"600": {"description": "OK"}}
+      },
+      "put": {"operationId": "getPet", "x-auth-type": "User", "responses": {"200": {}}}
+    }
+  }
+}`,
+			issues: []string{
+				"error: /info/version: version is required",
+				"error: /basePath: basePath must start with '/'",
+				"error: /paths/~1pets~1{id}/get/consumes: file parameters require multipart/form-data or application/x-www-form-urlencoded",
+				"error: /paths/~1pets~1{id}/get/parameters: body and formData parameters cannot be used together",
+				"error: /paths/~1pets~1{id}/get/responses/600: invalid response code 600",
+				"error: /paths/~1pets~1{id}/get: path parameter id is not declared",
+				"error: /paths/~1pets~1{id}/put/operationId: duplicate operationId getPet (also used by /paths/~1pets~1{id}/get)",
+				"error: /paths/~1pets~1{id}/put/responses/200/description: description is required",
+				"error: /paths/~1pets~1{id}/put/x-auth-type: invalid auth type User (available: Any, None, Application, Application User, Application & Application User)",
+				"error: /paths/~1pets~1{id}/put: path parameter id is not declared",
+			},
+		},
+		{
+			name: "scopes of x-wso2-security",
+			def: `{
+  "swagger": "2.0",
+  "info": {"title": "pets", "version": "1.0.0"},
+  "x-wso2-security": {
+    "apim": {
+      "x-wso2-scopes": [
+        {"key": "pets_read", "name": "read", "roles": "admin"},
+        {"key": "pets_write", "name": "write", "roles": ""}
+      ]
+    }
+  },
+  "paths": {
+    "/pets": {
+      "get": {"x-scope": "pets_read", "responses": {"200": {"description": "OK"}}},
+      "post": {"x-scope": "pets_delete", "responses": {"200": {"description": "OK"}}}
+    }
+  }
+}`,
+			issues: []string{
+				"warning: /x-wso2-security/apim/x-wso2-scopes/1/roles: no roles are assigned to the scope pets_write",
+				"error: /paths/~1pets/post/x-scope: scope pets_delete is not defined in x-wso2-security",
+				"warning: /x-wso2-security: scope pets_write is not used by any resource",
+			},
+		},
+		{
+			name: "invalid OpenAPI 3",
+			def: `{
+  "openapi": "3.0.0",
+  "info": {"title": "pets", "version": "1.0.0"},
+  "paths": {
+    "/pets": {
+      "post": {
+        "parameters": [{"name": "session", "in": "cookie"}],
+        "requestBody": {"content": {}},
+        "responses": {"200": {"$ref": "#/components/responses/OK"}}
+      }
+    }
+  }
+}`,
+			issues: []string{
+				"error: /paths/~1pets/post/parameters/0: either schema or content is required",
+				"error: /paths/~1pets/post/requestBody/content: content is required",
+				"error: /paths/~1pets/post/responses/200/$ref: cannot resolve the reference #/components/responses/OK",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issues, err := APIDefinition(tt.def).Lint()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			got := []string{}
+			for _, issue := range issues {
+				got = append(got, issue.String())
+			}
+			if !reflect.DeepEqual(got, tt.issues) {
+				t.Errorf("issues = %q, want %q", got, tt.issues)
+			}
+		})
+	}
+}
@@ -0,0 +1,865 @@
+package wso2am
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime/multipart"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+type (
+	// GraphQLSchema is a GraphQL schema parsed from the SDL (schema definition language).
+	GraphQLSchema struct {
+		// SDL is the source of the schema.
+		SDL string
+		// Types maps the names of the defined types to their kinds (type, interface, enum, ...).
+		Types map[string]string
+		// Operations are the fields of the root operation types.
+		Operations []GraphQLOperation
+	}
+	// GraphQLOperation is a field of the Query, Mutation or Subscription type.
+	GraphQLOperation struct {
+		Type GraphQLOperationType `json:"type"`
+		Name string               `json:"name"`
+	}
+	GraphQLOperationType string
+
+	// GraphQLSyntaxError is an error in the SDL.
+	GraphQLSyntaxError struct {
+		Line    int
+		Column  int
+		Message string
+	}
+)
+
+const (
+	GraphQLOperationQuery        GraphQLOperationType = "QUERY"
+	GraphQLOperationMutation     GraphQLOperationType = "MUTATION"
+	GraphQLOperationSubscription GraphQLOperationType = "SUBSCRIPTION"
+)
+
+var graphQLBuiltinScalars = []string{"Int", "Float", "String", "Boolean", "ID"}
+
+func (e *GraphQLSyntaxError) Error() string {
+	return fmt.Sprintf("%d:%d: %s", e.Line, e.Column, e.Message)
+}
+
+// LoadGraphQLSchema reads and parses the SDL file.
+func LoadGraphQLSchema(path string) (*GraphQLSchema, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	schema, err := ParseGraphQLSchema(string(data))
+	if err != nil {
+		return nil, fmt.Errorf("invalid GraphQL schema %s: %v", path, err)
+	}
+	return schema, nil
+}
+
+// ParseGraphQLSchema parses the SDL.
+// Besides the syntax, it checks the duplicated types, the references to undefined types and the root operation types.
+func ParseGraphQLSchema(sdl string) (*GraphQLSchema, error) {
+	p := &graphQLParser{
+		lexer: &graphQLLexer{src: sdl, line: 1, column: 1},
+		types: map[string]string{},
+		roots: map[GraphQLOperationType]string{},
+	}
+	if err := p.parse(); err != nil {
+		return nil, err
+	}
+	return p.schema(sdl)
+}
+
+// APIOperations returns the operations as the API operations.
+func (s *GraphQLSchema) APIOperations() []APIOperation {
+	operations := []APIOperation{}
+	for _, op := range s.Operations {
+		operations = append(operations, APIOperation{
+			Target: op.Name,
+			Verb:   string(op.Type),
+		})
+	}
+	return operations
+}
+
+type (
+	graphQLTokenKind int
+	graphQLToken     struct {
+		kind   graphQLTokenKind
+		value  string
+		line   int
+		column int
+	}
+	graphQLLexer struct {
+		src    string
+		pos    int
+		line   int
+		column int
+	}
+)
+
+const (
+	graphQLEOF graphQLTokenKind = iota
+	graphQLPunctuator
+	graphQLName
+	graphQLInt
+	graphQLFloat
+	graphQLString
+)
+
+func (t graphQLToken) String() string {
+	switch t.kind {
+	case graphQLEOF:
+		return "<EOF>"
+	case graphQLString:
+		return "string"
+	}
+	return fmt.Sprintf("%q", t.value)
+}
+
+func (l *graphQLLexer) errorf(line, column int, format string, args ...interface{}) error {
+	return &GraphQLSyntaxError{Line: line, Column: column, Message: fmt.Sprintf(format, args...)}
+}
+
+func (l *graphQLLexer) peekByte(offset int) byte {
+	if l.pos+offset < len(l.src) {
+		return l.src[l.pos+offset]
+	}
+	return 0
+}
+
+func (l *graphQLLexer) advance(n int) {
+	for i := 0; i < n && l.pos < len(l.src); i++ {
+		if l.src[l.pos] == '\n' {
+			l.line++
+			l.column = 1
+		} else {
+			l.column++
+		}
+		l.pos++
+	}
+}
+
+func (l *graphQLLexer) skipIgnored() {
+	for l.pos < len(l.src) {
+		switch ch := l.src[l.pos]; {
+		case ch == ' ' || ch == '\t' || ch == '\n' || ch == '\r' || ch == ',':
+			l.advance(1)
+		case ch == '#':
+			for l.pos < len(l.src) && l.src[l.pos] != '\n' {
+				l.advance(1)
+			}
+		case strings.HasPrefix(l.src[l.pos:], "\uFEFF"):
+			l.pos += len("\uFEFF")
+		default:
+			return
+		}
+	}
+}
+
+func (l *graphQLLexer) next() (graphQLToken, error) {
+	l.skipIgnored()
+	tok := graphQLToken{line: l.line, column: l.column}
+	if l.pos >= len(l.src) {
+		tok.kind = graphQLEOF
+		return tok, nil
+	}
+	ch := l.src[l.pos]
+	switch {
+	case strings.IndexByte("!$&()=:@[]{}|", ch) >= 0:
+		tok.kind = graphQLPunctuator
+		tok.value = string(ch)
+		l.advance(1)
+	case ch == '.':
+		if !strings.HasPrefix(l.src[l.pos:], "...") {
+			return tok, l.errorf(tok.line, tok.column, "unexpected character '.'")
+		}
+		tok.kind = graphQLPunctuator
+		tok.value = "..."
+		l.advance(3)
+	case ch == '_' || isLetter(ch):
+		start := l.pos
+		for l.pos < len(l.src) && (l.src[l.pos] == '_' || isLetter(l.src[l.pos]) || isDigit(l.src[l.pos])) {
+			l.advance(1)
+		}
+		tok.kind = graphQLName
+		tok.value = l.src[start:l.pos]
+	case ch == '-' || isDigit(ch):
+		return l.number(tok)
+	case ch == '"':
+		return l.string(tok)
+	default:
+		r, _ := utf8.DecodeRuneInString(l.src[l.pos:])
+		return tok, l.errorf(tok.line, tok.column, "unexpected character %q", r)
+	}
+	return tok, nil
+}
+
+func (l *graphQLLexer) number(tok graphQLToken) (graphQLToken, error) {
+	start := l.pos
+	tok.kind = graphQLInt
+	if l.peekByte(0) == '-' {
+		l.advance(1)
+	}
+	digits := func() error {
+		if !isDigit(l.peekByte(0)) {
+			return l.errorf(l.line, l.column, "invalid number: expected digit")
+		}
+		for isDigit(l.peekByte(0)) {
+			l.advance(1)
+		}
+		return nil
+	}
+	if err := digits(); err != nil {
+		return tok, err
+	}
+	if l.peekByte(0) == '.' {
+		tok.kind = graphQLFloat
+		l.advance(1)
+		if err := digits(); err != nil {
+			return tok, err
+		}
+	}
+	if c := l.peekByte(0); c == 'e' || c == 'E' {
+		tok.kind = graphQLFloat
+		l.advance(1)
+		if c := l.peekByte(0); c == '+' || c == '-' {
+			l.advance(1)
+		}
+		if err := digits(); err != nil {
+			return tok, err
+		}
+	}
+	if c := l.peekByte(0); c == '_' || c == '.' || isLetter(c) {
+		return tok, l.errorf(l.line, l.column, "invalid number: unexpected character %q", c)
+	}
+	tok.value = l.src[start:l.pos]
+	return tok, nil
+}
+
+func (l *graphQLLexer) string(tok graphQLToken) (graphQLToken, error) {
+	tok.kind = graphQLString
+	if strings.HasPrefix(l.src[l.pos:], `"""`) {
+		l.advance(3)
+		start := l.pos
+		for l.pos < len(l.src) {
+			if strings.HasPrefix(l.src[l.pos:], `\"""`) {
+				l.advance(4)
+				continue
+			}
+			if strings.HasPrefix(l.src[l.pos:], `"""`) {
+				tok.value = l.src[start:l.pos]
+				l.advance(3)
+				return tok, nil
+			}
+			l.advance(1)
+		}
+		return tok, l.errorf(tok.line, tok.column, "unterminated block string")
+	}
+	l.advance(1)
+	start := l.pos
+	for l.pos < len(l.src) {
+		switch l.src[l.pos] {
+		case '"':
+			tok.value = l.src[start:l.pos]
+			l.advance(1)
+			return tok, nil
+		case '\n', '\r':
+			return tok, l.errorf(tok.line, tok.column, "unterminated string")
+		case '\\':
+			if strings.IndexByte(`"\/bfnrtu`, l.peekByte(1)) < 0 {
+				return tok, l.errorf(l.line, l.column, "invalid escape sequence")
+			}
+			l.advance(2)
+		default:
+			l.advance(1)
+		}
+	}
+	return tok, l.errorf(tok.line, tok.column, "unterminated string")
+}
+
+func isLetter(c byte) bool {
+	return 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z'
+}
+
+func isDigit(c byte) bool {
+	return '0' <= c && c <= '9'
+}
+
+type graphQLParser struct {
+	lexer *graphQLLexer
+	tok   graphQLToken
+	// types maps the defined type names to the kinds.
+	types map[string]string
+	// fields maps the object type names to the field names.
+	fields map[string][]string
+	// references are the referred type names with the positions.
+	references []graphQLToken
+	// roots maps the operation types to the root type names defined by the schema definition.
+	roots map[GraphQLOperationType]string
+}
+
+func (p *graphQLParser) nextToken() error {
+	tok, err := p.lexer.next()
+	if err != nil {
+		return err
+	}
+	p.tok = tok
+	return nil
+}
+
+func (p *graphQLParser) errorf(format string, args ...interface{}) error {
+	return p.lexer.errorf(p.tok.line, p.tok.column, format, args...)
+}
+
+func (p *graphQLParser) peek(kind graphQLTokenKind, value string) bool {
+	return p.tok.kind == kind && (value == "" || p.tok.value == value)
+}
+
+// skip consumes the token if it matches.
+func (p *graphQLParser) skip(kind graphQLTokenKind, value string) (bool, error) {
+	if !p.peek(kind, value) {
+		return false, nil
+	}
+	return true, p.nextToken()
+}
+
+func (p *graphQLParser) expect(kind graphQLTokenKind, value string) (graphQLToken, error) {
+	tok := p.tok
+	if !p.peek(kind, value) {
+		expected := value
+		if expected == "" {
+			expected = map[graphQLTokenKind]string{graphQLName: "name", graphQLString: "string"}[kind]
+		} else {
+			expected = fmt.Sprintf("%q", expected)
+		}
+		return tok, p.errorf("expected %s, found %s", expected, p.tok)
+	}
+	return tok, p.nextToken()
+}
+
+func (p *graphQLParser) parse() error {
+	p.fields = map[string][]string{}
+	if err := p.nextToken(); err != nil {
+		return err
+	}
+	if p.peek(graphQLEOF, "") {
+		return p.errorf("empty schema")
+	}
+	for !p.peek(graphQLEOF, "") {
+		if err := p.definition(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *graphQLParser) description() error {
+	_, err := p.skip(graphQLString, "")
+	return err
+}
+
+func (p *graphQLParser) definition() error {
+	if err := p.description(); err != nil {
+		return err
+	}
+	extend, err := p.skip(graphQLName, "extend")
+	if err != nil {
+		return err
+	}
+	if !p.peek(graphQLName, "") {
+		return p.errorf("expected definition, found %s", p.tok)
+	}
+	keyword := p.tok
+	switch keyword.value {
+	case "schema":
+		return p.schemaDefinition()
+	case "scalar", "type", "interface", "union", "enum", "input":
+		return p.typeDefinition(extend)
+	case "directive":
+		if extend {
+			return p.errorf("directive cannot be extended")
+		}
+		return p.directiveDefinition()
+	case "query", "mutation", "subscription", "fragment":
+		return p.errorf("executable definition %q is not allowed in the schema", keyword.value)
+	default:
+		return p.errorf("unexpected %s", keyword)
+	}
+}
+
+func (p *graphQLParser) schemaDefinition() error {
+	if err := p.nextToken(); err != nil {
+		return err
+	}
+	if err := p.directives(); err != nil {
+		return err
+	}
+	if !p.peek(graphQLPunctuator, "{") {
+		// extend schema @directive
+		return nil
+	}
+	if err := p.nextToken(); err != nil {
+		return err
+	}
+	for !p.peek(graphQLPunctuator, "}") {
+		opTok, err := p.expect(graphQLName, "")
+		if err != nil {
+			return err
+		}
+		op := GraphQLOperationType(strings.ToUpper(opTok.value))
+		if op != GraphQLOperationQuery && op != GraphQLOperationMutation && op != GraphQLOperationSubscription {
+			return p.lexer.errorf(opTok.line, opTok.column, "unknown operation type %q", opTok.value)
+		}
+		if _, ok := p.roots[op]; ok {
+			return p.lexer.errorf(opTok.line, opTok.column, "duplicated operation type %q", opTok.value)
+		}
+		if _, err := p.expect(graphQLPunctuator, ":"); err != nil {
+			return err
+		}
+		typeTok, err := p.expect(graphQLName, "")
+		if err != nil {
+			return err
+		}
+		p.references = append(p.references, typeTok)
+		p.roots[op] = typeTok.value
+	}
+	return p.nextToken()
+}
+
+func (p *graphQLParser) typeDefinition(extend bool) error {
+	kind := p.tok.value
+	if err := p.nextToken(); err != nil {
+		return err
+	}
+	nameTok, err := p.expect(graphQLName, "")
+	if err != nil {
+		return err
+	}
+	name := nameTok.value
+	if !extend {
+		if _, ok := p.types[name]; ok || containsString(graphQLBuiltinScalars, name) {
+			return p.lexer.errorf(nameTok.line, nameTok.column, "type %q is already defined", name)
+		}
+		p.types[name] = kind
+	} else {
+		p.references = append(p.references, nameTok)
+	}
+
+	if kind == "type" || kind == "interface" {
+		if ok, err := p.skip(graphQLName, "implements"); err != nil {
+			return err
+		} else if ok {
+			if _, err := p.skip(graphQLPunctuator, "&"); err != nil {
+				return err
+			}
+			for {
+				tok, err := p.expect(graphQLName, "")
+				if err != nil {
+					return err
+				}
+				p.references = append(p.references, tok)
+				// the interfaces may be separated by "&" or, in the legacy syntax, by spaces or commas
+				if ok, err := p.skip(graphQLPunctuator, "&"); err != nil {
+					return err
+				} else if !ok && !(p.peek(graphQLName, "") && !p.peekDefinitionKeyword()) {
+					break
+				}
+			}
+		}
+	}
+	if err := p.directives(); err != nil {
+		return err
+	}
+
+	switch kind {
+	case "type", "interface":
+		if p.peek(graphQLPunctuator, "{") {
+			return p.fieldsDefinition(name)
+		}
+	case "input":
+		if p.peek(graphQLPunctuator, "{") {
+			if err := p.nextToken(); err != nil {
+				return err
+			}
+			for !p.peek(graphQLPunctuator, "}") {
+				if err := p.inputValueDefinition(); err != nil {
+					return err
+				}
+			}
+			return p.nextToken()
+		}
+	case "enum":
+		if p.peek(graphQLPunctuator, "{") {
+			if err := p.nextToken(); err != nil {
+				return err
+			}
+			for !p.peek(graphQLPunctuator, "}") {
+				if err := p.description(); err != nil {
+					return err
+				}
+				tok, err := p.expect(graphQLName, "")
+				if err != nil {
+					return err
+				}
+				if tok.value == "true" || tok.value == "false" || tok.value == "null" {
+					return p.lexer.errorf(tok.line, tok.column, "invalid enum value %q", tok.value)
+				}
+				if err := p.directives(); err != nil {
+					return err
+				}
+			}
+			return p.nextToken()
+		}
+	case "union":
+		if ok, err := p.skip(graphQLPunctuator, "="); err != nil {
+			return err
+		} else if ok {
+			if _, err := p.skip(graphQLPunctuator, "|"); err != nil {
+				return err
+			}
+			for {
+				tok, err := p.expect(graphQLName, "")
+				if err != nil {
+					return err
+				}
+				p.references = append(p.references, tok)
+				if ok, err := p.skip(graphQLPunctuator, "|"); err != nil {
+					return err
+				} else if !ok {
+					break
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// peekDefinitionKeyword returns true if the current name token starts the next definition.
+func (p *graphQLParser) peekDefinitionKeyword() bool {
+	return containsString([]string{"schema", "scalar", "type", "interface", "union", "enum", "input", "directive", "extend"}, p.tok.value)
+}
+
+func (p *graphQLParser) fieldsDefinition(typeName string) error {
+	if _, err := p.expect(graphQLPunctuator, "{"); err != nil {
+		return err
+	}
+	if p.peek(graphQLPunctuator, "}") {
+		return p.errorf("type %s must define one or more fields", typeName)
+	}
+	for !p.peek(graphQLPunctuator, "}") {
+		if err := p.description(); err != nil {
+			return err
+		}
+		nameTok, err := p.expect(graphQLName, "")
+		if err != nil {
+			return err
+		}
+		if containsString(p.fields[typeName], nameTok.value) {
+			return p.lexer.errorf(nameTok.line, nameTok.column, "field %s.%s is already defined", typeName, nameTok.value)
+		}
+		p.fields[typeName] = append(p.fields[typeName], nameTok.value)
+		if p.peek(graphQLPunctuator, "(") {
+			if err := p.nextToken(); err != nil {
+				return err
+			}
+			for !p.peek(graphQLPunctuator, ")") {
+				if err := p.inputValueDefinition(); err != nil {
+					return err
+				}
+			}
+			if err := p.nextToken(); err != nil {
+				return err
+			}
+		}
+		if _, err := p.expect(graphQLPunctuator, ":"); err != nil {
+			return err
+		}
+		if err := p.typeReference(); err != nil {
+			return err
+		}
+		if err := p.directives(); err != nil {
+			return err
+		}
+	}
+	return p.nextToken()
+}
+
+func (p *graphQLParser) inputValueDefinition() error {
+	if err := p.description(); err != nil {
+		return err
+	}
+	if _, err := p.expect(graphQLName, ""); err != nil {
+		return err
+	}
+	if _, err := p.expect(graphQLPunctuator, ":"); err != nil {
+		return err
+	}
+	if err := p.typeReference(); err != nil {
+		return err
+	}
+	if ok, err := p.skip(graphQLPunctuator, "="); err != nil {
+		return err
+	} else if ok {
+		if err := p.value(); err != nil {
+			return err
+		}
+	}
+	return p.directives()
+}
+
+func (p *graphQLParser) typeReference() error {
+	if ok, err := p.skip(graphQLPunctuator, "["); err != nil {
+		return err
+	} else if ok {
+		if err := p.typeReference(); err != nil {
+			return err
+		}
+		if _, err := p.expect(graphQLPunctuator, "]"); err != nil {
+			return err
+		}
+	} else {
+		tok, err := p.expect(graphQLName, "")
+		if err != nil {
+			return err
+		}
+		p.references = append(p.references, tok)
+	}
+	_, err := p.skip(graphQLPunctuator, "!")
+	return err
+}
+
+func (p *graphQLParser) directives() error {
+	for p.peek(graphQLPunctuator, "@") {
+		if err := p.nextToken(); err != nil {
+			return err
+		}
+		if _, err := p.expect(graphQLName, ""); err != nil {
+			return err
+		}
+		if ok, err := p.skip(graphQLPunctuator, "("); err != nil {
+			return err
+		} else if ok {
+			for !p.peek(graphQLPunctuator, ")") {
+				if _, err := p.expect(graphQLName, ""); err != nil {
+					return err
+				}
+				if _, err := p.expect(graphQLPunctuator, ":"); err != nil {
+					return err
+				}
+				if err := p.value(); err != nil {
+					return err
+				}
+			}
+			if err := p.nextToken(); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (p *graphQLParser) value() error {
+	switch {
+	case p.peek(graphQLPunctuator, "$"):
+		return p.errorf("variables are not allowed in the schema")
+	case p.peek(graphQLInt, ""), p.peek(graphQLFloat, ""), p.peek(graphQLString, ""), p.peek(graphQLName, ""):
+		return p.nextToken()
+	case p.peek(graphQLPunctuator, "["):
+		if err := p.nextToken(); err != nil {
+			return err
+		}
+		for !p.peek(graphQLPunctuator, "]") {
+			if p.peek(graphQLEOF, "") {
+				return p.errorf("expected \"]\", found %s", p.tok)
+			}
+			if err := p.value(); err != nil {
+				return err
+			}
+		}
+		return p.nextToken()
+	case p.peek(graphQLPunctuator, "{"):
+		if err := p.nextToken(); err != nil {
+			return err
+		}
+		for !p.peek(graphQLPunctuator, "}") {
+			if _, err := p.expect(graphQLName, ""); err != nil {
+				return err
+			}
+			if _, err := p.expect(graphQLPunctuator, ":"); err != nil {
+				return err
+			}
+			if err := p.value(); err != nil {
+				return err
+			}
+		}
+		return p.nextToken()
+	}
+	return p.errorf("expected value, found %s", p.tok)
+}
+
+func (p *graphQLParser) directiveDefinition() error {
+	if err := p.nextToken(); err != nil {
+		return err
+	}
+	if _, err := p.expect(graphQLPunctuator, "@"); err != nil {
+		return err
+	}
+	if _, err := p.expect(graphQLName, ""); err != nil {
+		return err
+	}
+	if ok, err := p.skip(graphQLPunctuator, "("); err != nil {
+		return err
+	} else if ok {
+		for !p.peek(graphQLPunctuator, ")") {
+			if err := p.inputValueDefinition(); err != nil {
+				return err
+			}
+		}
+		if err := p.nextToken(); err != nil {
+			return err
+		}
+	}
+	if _, err := p.skip(graphQLName, "repeatable"); err != nil {
+		return err
+	}
+	if _, err := p.expect(graphQLName, "on"); err != nil {
+		return err
+	}
+	if _, err := p.skip(graphQLPunctuator, "|"); err != nil {
+		return err
+	}
+	for {
+		if _, err := p.expect(graphQLName, ""); err != nil {
+			return err
+		}
+		if ok, err := p.skip(graphQLPunctuator, "|"); err != nil {
+			return err
+		} else if !ok {
+			return nil
+		}
+	}
+}
+
+func (p *graphQLParser) schema(sdl string) (*GraphQLSchema, error) {
+	for _, ref := range p.references {
+		if _, ok := p.types[ref.value]; !ok && !containsString(graphQLBuiltinScalars, ref.value) {
+			return nil, p.lexer.errorf(ref.line, ref.column, "undefined type %q", ref.value)
+		}
+	}
+	// the root operation types default to Query, Mutation and Subscription
+	if len(p.roots) == 0 {
+		for op, name := range map[GraphQLOperationType]string{
+			GraphQLOperationQuery:        "Query",
+			GraphQLOperationMutation:     "Mutation",
+			GraphQLOperationSubscription: "Subscription",
+		} {
+			if p.types[name] == "type" {
+				p.roots[op] = name
+			}
+		}
+	}
+	if _, ok := p.roots[GraphQLOperationQuery]; !ok {
+		return nil, &GraphQLSyntaxError{Line: 1, Column: 1, Message: "the query root type is not defined"}
+	}
+	schema := &GraphQLSchema{
+		SDL:        sdl,
+		Types:      p.types,
+		Operations: []GraphQLOperation{},
+	}
+	for _, op := range []GraphQLOperationType{GraphQLOperationQuery, GraphQLOperationMutation, GraphQLOperationSubscription} {
+		name, ok := p.roots[op]
+		if !ok {
+			continue
+		}
+		if p.types[name] != "type" {
+			return nil, &GraphQLSyntaxError{Line: 1, Column: 1, Message: fmt.Sprintf("the %s root type %s must be an object type", strings.ToLower(string(op)), name)}
+		}
+		fields := append([]string{}, p.fields[name]...)
+		sort.Strings(fields)
+		for _, f := range fields {
+			schema.Operations = append(schema.Operations, GraphQLOperation{Type: op, Name: f})
+		}
+	}
+	return schema, nil
+}
+
+// NewGraphQLAPI creates a GraphQL API whose operations are derived from the schema.
+func (c *Client) NewGraphQLAPI(schema *GraphQLSchema) *APIDetail {
+	api := c.NewAPI()
+	api.Type = APITypeGraphQL
+	api.Definition = ""
+	api.Operations = schema.APIOperations()
+	return api
+}
+
+// CreateGraphQLAPI imports the schema and creates the GraphQL API.
+// GraphQL APIs are supported by publisher API v1 or later.
+func (c *Client) CreateGraphQLAPI(api *APIDetail, schema *GraphQLSchema) (*APIDetail, error) {
+	if err := c.CheckGraphQLSupported(); err != nil {
+		return nil, err
+	}
+	return c.importAPIV1("apis/import-graphql-schema", api, func(writer *multipart.Writer) error {
+		w, err := writer.CreateFormFile("file", "schema.graphql")
+		if err != nil {
+			return err
+		}
+		if _, err := io.WriteString(w, schema.SDL); err != nil {
+			return err
+		}
+		return writer.WriteField("type", string(APITypeGraphQL))
+	})
+}
+
+// GraphQLSchema returns the SDL of the GraphQL API.
+func (c *Client) GraphQLSchema(id string) (string, error) {
+	if err := c.CheckGraphQLSupported(); err != nil {
+		return "", err
+	}
+	var v struct {
+		Name             string `json:"name"`
+		SchemaDefinition string `json:"schemaDefinition"`
+	}
+	if err := c.get(c.publisherURL("apis/"+id+"/graphql-schema"), "apim:api_view", &v); err != nil {
+		return "", err
+	}
+	return v.SchemaDefinition, nil
+}
+
+// UpdateGraphQLSchema replaces the schema of the GraphQL API and its operations.
+func (c *Client) UpdateGraphQLSchema(id string, schema *GraphQLSchema) error {
+	if err := c.CheckGraphQLSupported(); err != nil {
+		return err
+	}
+	buf := new(bytes.Buffer)
+	writer := multipart.NewWriter(buf)
+	if err := writer.WriteField("schemaDefinition", schema.SDL); err != nil {
+		return err
+	}
+	if err := writer.Close(); err != nil {
+		return err
+	}
+	if err := c.put(c.publisherURL("apis/"+id+"/graphql-schema"), "apim:api_create", newBinaryRequestBody(buf.Bytes(), writer.FormDataContentType()), nil); err != nil {
+		return err
+	}
+	// the operations are not updated by the schema update
+	api, err := c.apiV1(id)
+	if err != nil {
+		return err
+	}
+	api.Operations = schema.APIOperations()
+	return c.updateAPIV1(api)
+}
+
+// CheckGraphQLSupported returns an error if the publisher API does not support the GraphQL APIs.
+func (c *Client) CheckGraphQLSupported() error {
+	if strings.HasPrefix(c.config.APIVersion, "v0.") {
+		return fmt.Errorf("GraphQL APIs are not supported by the publisher API %s; use v1 or later", c.config.APIVersion)
+	}
+	return nil
+}
@@ -0,0 +1,202 @@
+package wso2am
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseGraphQLSchema(t *testing.T) {
+	tests := []struct {
+		name       string
+		sdl        string
+		operations []GraphQLOperation
+		types      map[string]string
+	}{
+		{
+			name: "default root types",
+			sdl: `
+type Query {
+  pizzas: [Pizza!]!
+  pizza(id: ID!): Pizza
+}
+type Mutation {
+  order(pizza: ID!, quantity: Int = 1): Order
+}
+type Subscription {
+  orderStatus(order: ID!): String
+}
+type Pizza { id: ID! name: String price: Float }
+type Order { id: ID! }
+`,
+			operations: []GraphQLOperation{
+				{Type: GraphQLOperationQuery, Name: "pizza"},
+				{Type: GraphQLOperationQuery, Name: "pizzas"},
+				{Type: GraphQLOperationMutation, Name: "order"},
+				{Type: GraphQLOperationSubscription, Name: "orderStatus"},
+			},
+		},
+		{
+			name: "schema definition renames the root types",
+			sdl: `
+schema {
+  query: RootQuery
+  mutation: RootMutation
+}
+type RootQuery { menu: [String] }
+type RootMutation { reset: Boolean }
+type Query { ignored: String }
+`,
+			operations: []GraphQLOperation{
+				{Type: GraphQLOperationQuery, Name: "menu"},
+				{Type: GraphQLOperationMutation, Name: "reset"},
+			},
+		},
+		{
+			name: "descriptions, comments and directives",
+			sdl: `
+# comment
+"""
+The root query.
+It has a "quoted" word.
+"""
+type Query {
+  "single line description"
+  legacy: String @deprecated(reason: "use current")
+  current(filter: Filter = {tags: ["a", "b"], limit: 10}): [Item] # trailing comment
+}
+directive @cached(ttl: Int = 60) on FIELD_DEFINITION | OBJECT
+input Filter { tags: [String!] limit: Int }
+type Item @cached(ttl: 30) { id: ID! }
+`,
+			operations: []GraphQLOperation{
+				{Type: GraphQLOperationQuery, Name: "current"},
+				{Type: GraphQLOperationQuery, Name: "legacy"},
+			},
+		},
+		{
+			name: "all the kinds of the types and the extensions",
+			sdl: `
+scalar Date
+interface Node { id: ID! }
+type Pizza implements Node & Named { id: ID! name: String baked: Date }
+interface Named { name: String }
+union SearchResult = | Pizza | Topping
+type Topping { name: String }
+enum Size { SMALL MEDIUM LARGE }
+input PizzaInput { size: Size = MEDIUM }
+type Query { search(text: String): [SearchResult] }
+extend type Query { node(id: ID!): Node }
+`,
+			operations: []GraphQLOperation{
+				{Type: GraphQLOperationQuery, Name: "node"},
+				{Type: GraphQLOperationQuery, Name: "search"},
+			},
+			types: map[string]string{
+				"Date":         "scalar",
+				"Node":         "interface",
+				"Named":        "interface",
+				"Pizza":        "type",
+				"SearchResult": "union",
+				"Topping":      "type",
+				"Size":         "enum",
+				"PizzaInput":   "input",
+				"Query":        "type",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schema, err := ParseGraphQLSchema(tt.sdl)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(schema.Operations, tt.operations) {
+				t.Errorf("operations = %v, want %v", schema.Operations, tt.operations)
+			}
+			if tt.types != nil && !reflect.DeepEqual(schema.Types, tt.types) {
+				t.Errorf("types = %v, want %v", schema.Types, tt.types)
+			}
+		})
+	}
+}
+
+func TestParseGraphQLSchemaErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		sdl  string
+		// err is a part of the expected error message.
+		err string
+	}{
+		{
+			name: "undefined type",
+			sdl:  "type Query {\n  pizza: Pizza\n}",
+			err:  `2:10: undefined type "Pizza"`,
+		},
+		{
+			name: "duplicated type",
+			sdl:  "type Query { a: String }\ntype Query { b: String }",
+			err:  `2:6: type "Query" is already defined`,
+		},
+		{
+			name: "no query root type",
+			sdl:  "type Mutation { a: String }",
+			err:  "the query root type is not defined",
+		},
+		{
+			name: "root type is not an object type",
+			sdl:  "schema { query: Q }\ninput Q { a: String }",
+			err:  "must be an object type",
+		},
+		{
+			name: "unterminated string",
+			sdl:  "type Query {\n  \"description\n  a: String\n}",
+			err:  "2:3: unterminated string",
+		},
+		{
+			name: "unterminated block string",
+			sdl:  `type Query { """ a: String }`,
+			err:  "1:14: unterminated block string",
+		},
+		{
+			name: "missing field type",
+			sdl:  "type Query {\n  a:\n}",
+			err:  `3:1: expected name, found "}"`,
+		},
+		{
+			name: "unclosed fields",
+			sdl:  "type Query { a: String",
+			err:  "1:23: expected name, found <EOF>",
+		},
+		{
+			name: "unexpected character",
+			sdl:  "type Query { a: String ? }",
+			err:  "1:24: unexpected character '?'",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseGraphQLSchema(tt.sdl)
+			if err == nil {
+				t.Fatalf("expected an error containing %q", tt.err)
+			}
+			if !strings.Contains(err.Error(), tt.err) {
+				t.Errorf("error = %q, want %q", err.Error(), tt.err)
+			}
+		})
+	}
+}
+
+func TestGraphQLSchemaAPIOperations(t *testing.T) {
+	schema, err := ParseGraphQLSchema("type Query { a: String }\ntype Mutation { b: String }")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []APIOperation{
+		{Target: "a", Verb: "QUERY"},
+		{Target: "b", Verb: "MUTATION"},
+	}
+	if got := schema.APIOperations(); !reflect.DeepEqual(got, want) {
+		t.Errorf("APIOperations() = %v, want %v", got, want)
+	}
+}
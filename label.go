@@ -0,0 +1,115 @@
+package wso2am
+
+import (
+	"fmt"
+	"net/url"
+)
+
+type (
+	// Label is a microgateway label.  The APIs with the label are deployed to the microgateways of the label.
+	// https://github.com/wso2/carbon-apimgt/blob/master/components/apimgt/org.wso2.carbon.apimgt.rest.api.admin/src/gen/java/org/wso2/carbon/apimgt/rest/api/admin/dto/LabelDTO.java
+	Label struct {
+		ID          string   `json:"labelId,omitempty"`
+		Name        string   `json:"name"`
+		Description string   `json:"description,omitempty"`
+		AccessURLs  []string `json:"accessUrls"`
+	}
+)
+
+// Labels returns the microgateway labels.
+func (c *Client) Labels() ([]Label, error) {
+	var v struct {
+		Count int     `json:"count"`
+		List  []Label `json:"list"`
+	}
+	if err := c.get(c.publisherURL("labels"), "apim:api_view", &v); err != nil {
+		return nil, err
+	}
+	return v.List, nil
+}
+
+// FindLabel returns the label of the name with the admin API, which returns the IDs of the labels.
+// It returns nil if not found.
+func (c *Client) FindLabel(name string) (*Label, error) {
+	var v struct {
+		Count int     `json:"count"`
+		List  []Label `json:"list"`
+	}
+	if err := c.get(c.adminURL("labels"), "apim:label_read", &v); err != nil {
+		return nil, err
+	}
+	for _, l := range v.List {
+		if l.Name == name {
+			return &l, nil
+		}
+	}
+	return nil, nil
+}
+
+// LabelsByName returns the labels of the names.
+// The error suggests the close matches of the unknown labels.
+func (c *Client) LabelsByName(names []string) ([]Label, error) {
+	labels, err := c.Labels()
+	if err != nil {
+		return nil, err
+	}
+	available := []string{}
+	for _, l := range labels {
+		available = append(available, l.Name)
+	}
+	result := []Label{}
+	for _, name := range names {
+		if err := checkAvailable("label", name, available); err != nil {
+			return nil, err
+		}
+		for _, l := range labels {
+			if l.Name == name {
+				result = append(result, l)
+			}
+		}
+	}
+	return result, nil
+}
+
+// CreateLabel creates the label with the admin API.
+func (c *Client) CreateLabel(label *Label) (*Label, error) {
+	var v Label
+	if err := c.post(c.adminURL("labels"), "apim:label_manage", newJSONRequestBody(label), &v); err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
+
+// UpdateLabel updates the label with the admin API.
+func (c *Client) UpdateLabel(label *Label) (*Label, error) {
+	if label.ID == "" {
+		return nil, fmt.Errorf("ID of the label %s is required", label.Name)
+	}
+	var v Label
+	if err := c.put(c.adminURL("labels/"+url.PathEscape(label.ID)), "apim:label_manage", newJSONRequestBody(label), &v); err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
+
+// DeleteLabel deletes the label with the admin API.
+func (c *Client) DeleteLabel(id string) error {
+	return c.delete(c.adminURL("labels/"+url.PathEscape(id)), "apim:label_manage", nil)
+}
+
+// HasLabel returns true if the API is deployed to the microgateways of the label.
+func (a *APIDetail) HasLabel(name string) bool {
+	for _, l := range a.Labels {
+		if l.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// SearchAPIsByLabelRaw searches the APIs which have the label.
+func (c *Client) SearchAPIsByLabelRaw(query string, label string, entryc chan<- interface{}, errc chan<- error, done <-chan struct{}) {
+	c.SearchAPIsWithDetailRaw(query, func(api *APIDetail) bool {
+		return api.HasLabel(label)
+	}, entryc, errc, done)
+}
@@ -8,7 +8,6 @@ import (
 	"io/ioutil"
 	"mime/multipart"
 	"net/url"
-	"os"
 	"path/filepath"
 	"strings"
 
@@ -50,6 +49,10 @@ type (
 		SubscriptionAvailableTenants []string                `json:"subscriptionAvailableTenants,omitempty"`
 		BusinessInformation          *APIBusinessInformation `json:"businessInformation"`
 		CORSConfiguration            *APICORSConfiguration   `json:"corsConfiguration"`
+		// Operations are the operations of the API in publisher API v1 or later.
+		Operations []APIOperation `json:"operations,omitempty"`
+		// Labels are the microgateway labels the API is deployed to.
+		Labels []Label `json:"labels,omitempty"`
 	}
 	APIMaxTPS struct {
 		Sandbox    int `json:"sandbox"`
@@ -103,6 +106,8 @@ type (
 const (
 	APITransportHTTP  APITransport = "http"
 	APITransportHTTPS APITransport = "https"
+	APITransportWS    APITransport = "ws"
+	APITransportWSS   APITransport = "wss"
 
 	APIActionPublish            APIAction = "Publish"
 	APIActionDeployAsPrototype  APIAction = "Deploy as a Prototype"
@@ -119,13 +124,15 @@ const (
 	APIStatusRetired     APIStatus = "Retired"
 	APIStatusMaintenance APIStatus = "Maintenance"
 	APIStatusPrototyped  APIStatus = "Prototyped"
+	APIStatusBlocked     APIStatus = "Blocked"
 
 	APIVisibilityPublic     APIVisibility = "PUBLIC"
 	APIVisibilityPrivate    APIVisibility = "PRIVATE"
 	APIVisibilityRestricted APIVisibility = "RESTRICTED"
 
-	APITypeHTTP APIType = "HTTP"
-	APITypeWS   APIType = "WS"
+	APITypeHTTP    APIType = "HTTP"
+	APITypeWS      APIType = "WS"
+	APITypeGraphQL APIType = "GRAPHQL"
 )
 
 func (c *Client) NewAPI() *APIDetail {
@@ -178,25 +185,83 @@ func (c *Client) NewAPI() *APIDetail {
 	}
 }
 
+// NewWebSocketAPI creates a WebSocket API.
+// WebSocket APIs have no resources, so the definition is an empty Swagger document
+// which is required by the publisher API.
+func (c *Client) NewWebSocketAPI() *APIDetail {
+	api := c.NewAPI()
+	api.Type = APITypeWS
+	api.Definition = emptyAPIDefinition
+	api.Transport = []APITransport{APITransportWS, APITransportWSS}
+	api.CORSConfiguration = nil
+	return api
+}
+
+const emptyAPIDefinition APIDefinition = `{"swagger":"2.0","paths":{},"info":{"title":"","version":""}}`
+
+// NewWebSocketEndpointConfig creates the endpoint config of the WebSocket API.
+// The URLs must be ws:// or wss:// URLs.  The sandbox URL is optional.
+func NewWebSocketEndpointConfig(productionURL, sandboxURL string) (*APIEndpointConfig, error) {
+	// the gateway decides the protocol by the URL scheme, so the endpoint type is "http" as well as the HTTP APIs.
+	config := &APIEndpointConfig{
+		Type: "http",
+	}
+	if err := validateWebSocketURL(productionURL); err != nil {
+		return nil, err
+	}
+	config.ProductionEndpoints = &APIEndpoint{URL: productionURL}
+	if sandboxURL != "" {
+		if err := validateWebSocketURL(sandboxURL); err != nil {
+			return nil, err
+		}
+		config.SandboxEndpoints = &APIEndpoint{URL: sandboxURL}
+	}
+	return config, nil
+}
+
+func validateWebSocketURL(s string) error {
+	u, err := url.Parse(s)
+	if err != nil {
+		return err
+	}
+	if u.Scheme != "ws" && u.Scheme != "wss" || u.Host == "" {
+		return fmt.Errorf("invalid WebSocket endpoint %q (expected ws://HOST or wss://HOST)", s)
+	}
+	return nil
+}
+
+// ParseAPIType parses the API type case-insensitively.
+func ParseAPIType(s string) (APIType, error) {
+	for _, t := range []APIType{APITypeHTTP, APITypeWS, APITypeGraphQL} {
+		if strings.EqualFold(string(t), s) {
+			return t, nil
+		}
+	}
+	return "", fmt.Errorf("unsupported API type: %s (available: %s, %s, %s)", s, APITypeHTTP, APITypeWS, APITypeGraphQL)
+}
+
 func (a *APIDetail) SetEndpointConfig(endpointConfig *APIEndpointConfig) {
 	data, _ := json.Marshal(endpointConfig)
 	a.EndpointConfig = string(data)
 }
 
 func NewAPIDefinitionFromFile(path string) (APIDefinition, error) {
-	f, err := os.Open(path)
+	return newAPIDefinitionFromFile(path, nil)
+}
+
+func newAPIDefinitionFromFile(path string, t *Template) (APIDefinition, error) {
+	data, err := t.renderFile(path)
 	if err != nil {
 		return "", err
 	}
-	defer f.Close()
 
 	ext := filepath.Ext(path)
 	ext = strings.ToLower(ext)
 	switch ext {
 	case ".json":
-		return NewAPIDefinitionFromJSON(f)
+		return NewAPIDefinitionFromJSON(bytes.NewReader(data))
 	case ".yaml", ".yml":
-		return NewAPIDefinitionFromYAML(f)
+		return NewAPIDefinitionFromYAML(bytes.NewReader(data))
 	default:
 		return "", fmt.Errorf("unsupported swagger file format: %s", path)
 	}
@@ -219,7 +284,7 @@ func NewAPIDefinitionFromYAML(r io.Reader) (APIDefinition, error) {
 	if err := yaml.Unmarshal(data, &v); err != nil {
 		return "", err
 	}
-	j, err := json.Marshal(v)
+	j, err := json.Marshal(normalizeYAML(v))
 	if err != nil {
 		return "", err
 	}
@@ -249,6 +314,63 @@ func (c *Client) SearchAPIsRaw(query string, entryc chan<- interface{}, errc cha
 	})
 }
 
+// SearchAPIsByTypeRaw searches the APIs of the type.
+func (c *Client) SearchAPIsByTypeRaw(query string, apiType APIType, entryc chan<- interface{}, errc chan<- error, done <-chan struct{}) {
+	c.SearchAPIsWithDetailRaw(query, func(api *APIDetail) bool {
+		return api.Type == apiType
+	}, entryc, errc, done)
+}
+
+// SearchAPIsWithDetailRaw searches the APIs whose details match.
+// The search result of the publisher API does not contain the details like the type and the labels,
+// so the detail of each API is fetched.
+func (c *Client) SearchAPIsWithDetailRaw(query string, match func(*APIDetail) bool, entryc chan<- interface{}, errc chan<- error, done <-chan struct{}) {
+	var (
+		c2    = make(chan interface{})
+		errc2 = make(chan error)
+		done2 = make(chan struct{})
+	)
+	go func() {
+		defer close(c2)
+		c.SearchAPIsRaw(query, c2, errc2, done2)
+	}()
+	stop := func(err error) {
+		defer close(done2)
+		select {
+		case errc <- err:
+		case <-done:
+			return
+		}
+		// wait for the consumer to stop the search
+		<-done
+	}
+	for {
+		select {
+		case v, ok := <-c2:
+			if !ok {
+				return
+			}
+			detail, err := c.API(c.ConvertToAPI(v).ID)
+			if err != nil {
+				stop(err)
+				return
+			}
+			if !match(detail) {
+				continue
+			}
+			select {
+			case entryc <- v:
+			case <-done:
+				close(done2)
+				return
+			}
+		case err := <-errc2:
+			stop(err)
+			return
+		}
+	}
+}
+
 func (c *Client) searchAPIs(query string, q *PageQuery) (*PageResponse, error) {
 	params := pageQueryParams(q)
 	params.Add("query", query)
@@ -259,6 +381,52 @@ func (c *Client) searchAPIs(query string, q *PageQuery) (*PageResponse, error) {
 	return &v, nil
 }
 
+// FindAPIByContextVersion finds the API which has the specified context and version.
+// It returns nil if no API matched.
+func (c *Client) FindAPIByContextVersion(context, version string) (*API, error) {
+	result, err := c.SearchResultToSlice(func(entryc chan<- interface{}, errc chan<- error, done <-chan struct{}) {
+		c.SearchAPIsRaw(fmt.Sprintf("context:%s", context), entryc, errc, done)
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range result {
+		api := c.ConvertToAPI(v)
+		if normalizeContext(api.Context) == normalizeContext(context) && api.Version == version {
+			return api, nil
+		}
+	}
+	return nil, nil
+}
+
+// FindAPI finds the API by the exact name and the version in the publisher.
+// Unlike FindStoreAPI, it finds the APIs of any status, e.g. BLOCKED and RETIRED.
+// If the version is empty, the name must identify a single API.
+// It returns nil if not found.
+func (c *Client) FindAPI(name, version string) (*API, error) {
+	result, err := c.SearchResultToSlice(func(entryc chan<- interface{}, errc chan<- error, done <-chan struct{}) {
+		c.SearchAPIsRaw("name:"+name, entryc, errc, done)
+	})
+	if err != nil {
+		return nil, err
+	}
+	apis := make([]*API, len(result))
+	for i, v := range result {
+		apis[i] = c.ConvertToAPI(v)
+	}
+	i, err := findNameVersion(name, version, len(apis), func(i int) (string, string) {
+		return apis[i].Name, apis[i].Version
+	})
+	if err != nil || i < 0 {
+		return nil, err
+	}
+	return apis[i], nil
+}
+
+func normalizeContext(context string) string {
+	return strings.TrimSuffix(strings.TrimPrefix(context, "/"), "/")
+}
+
 func (c *Client) ChangeAPIStatus(id string, action APIAction) error {
 	params := url.Values{}
 	params.Add("apiId", id)
@@ -308,7 +476,12 @@ func (c *Client) APIDefinition(id string) (map[string]interface{}, error) {
 	return v, nil
 }
 
+// UpdateAPIDefinition updates the API definition.
+// The definition is validated by APIDefinition.Validate before the update.
 func (c *Client) UpdateAPIDefinition(id string, definition APIDefinition) (map[string]interface{}, error) {
+	if err := definition.Validate(); err != nil {
+		return nil, err
+	}
 	buf := new(bytes.Buffer)
 	writer := multipart.NewWriter(buf)
 	defer writer.Close()
@@ -326,23 +499,46 @@ func (c *Client) UpdateAPIDefinition(id string, definition APIDefinition) (map[s
 	return v, nil
 }
 
+// UploadThumbnail uploads the image read from the reader as the thumbnail of the API.
+// The image is uploaded as is with the detected media type.  Use UploadThumbnailImage to validate it before the upload.
 func (c *Client) UploadThumbnail(id string, thumbnail io.Reader) (*APIUploadThumbnailResponse, error) {
-	buf := new(bytes.Buffer)
-	writer := multipart.NewWriter(buf)
-	w, err := writer.CreateFormField("file")
+	data, err := ioutil.ReadAll(thumbnail)
 	if err != nil {
 		return nil, err
 	}
-	if _, err := io.Copy(w, thumbnail); err != nil {
+	return c.uploadThumbnail(id, &ThumbnailImage{
+		Data:      data,
+		MediaType: detectThumbnailMediaType(data),
+	})
+}
+
+// UploadThumbnailImage validates the image and uploads it as the thumbnail of the API.
+func (c *Client) UploadThumbnailImage(id string, img *ThumbnailImage) (*APIUploadThumbnailResponse, error) {
+	if err := img.Validate(); err != nil {
+		return nil, err
+	}
+	return c.uploadThumbnail(id, img)
+}
+
+func (c *Client) uploadThumbnail(id string, img *ThumbnailImage) (*APIUploadThumbnailResponse, error) {
+	body, err := newThumbnailRequestBody(img)
+	if err != nil {
 		return nil, err
 	}
 	var v APIUploadThumbnailResponse
-	if err := c.post(c.publisherURL("apis/"+id+"/thumbnail"), "apim:api_create", newBinaryRequestBody(buf.Bytes(), writer.FormDataContentType()), &v); err != nil {
+	if err := c.post(c.publisherURL("apis/"+id+"/thumbnail"), "apim:api_create", body, &v); err != nil {
 		return nil, err
 	}
 	return &v, nil
 }
 
+// Thumbnail downloads the thumbnail of the API to the writer.
 func (c *Client) Thumbnail(id string, thumbnail io.Writer) error {
-	return c.get(c.publisherURL("apis/"+id+"/thumbnail"), "apim:api_view", thumbnail)
+	_, err := c.ThumbnailWithMediaType(id, thumbnail)
+	return err
+}
+
+// ThumbnailWithMediaType downloads the thumbnail of the API to the writer, and returns its media type.
+func (c *Client) ThumbnailWithMediaType(id string, thumbnail io.Writer) (string, error) {
+	return c.downloadThumbnail(c.publisherURL("apis/"+id+"/thumbnail"), "apim:api_view", thumbnail)
 }
@@ -0,0 +1,244 @@
+package wso2am
+
+import (
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+)
+
+type (
+	// APIProduct is an API Product which bundles the resources of several APIs.
+	// https://github.com/wso2/carbon-apimgt/blob/master/components/apimgt/org.wso2.carbon.apimgt.rest.api.publisher.v1/src/gen/java/org/wso2/carbon/apimgt/rest/api/publisher/v1/dto/APIProductInfoDTO.java
+	APIProduct struct {
+		ID           string `json:"id,omitempty"`
+		Name         string `json:"name"`
+		Context      string `json:"context"`
+		Description  string `json:"description"`
+		Provider     string `json:"provider"`
+		HasThumbnail bool   `json:"hasThumbnail"`
+		State        string `json:"state,omitempty"`
+	}
+	// https://github.com/wso2/carbon-apimgt/blob/master/components/apimgt/org.wso2.carbon.apimgt.rest.api.publisher.v1/src/gen/java/org/wso2/carbon/apimgt/rest/api/publisher/v1/dto/APIProductDTO.java
+	APIProductDetail struct {
+		APIProduct
+		Visibility          APIVisibility           `json:"visibility"`
+		VisibleRoles        []string                `json:"visibleRoles"`
+		GatewayEnvironments []string                `json:"gatewayEnvironments"`
+		Transport           []APITransport          `json:"transport"`
+		Tags                []string                `json:"tags"`
+		Policies            []string                `json:"policies"`
+		BusinessInformation *APIBusinessInformation `json:"businessInformation,omitempty"`
+		CORSConfiguration   *APICORSConfiguration   `json:"corsConfiguration,omitempty"`
+		APIs                []ProductAPI            `json:"apis"`
+	}
+	// ProductAPI is an API and its operations included in the API Product.
+	ProductAPI struct {
+		APIID      string         `json:"apiId"`
+		Name       string         `json:"name,omitempty"`
+		Version    string         `json:"version,omitempty"`
+		Operations []APIOperation `json:"operations"`
+	}
+	// APIOperation is an operation of the API.
+	APIOperation struct {
+		Target           string   `json:"target"`
+		Verb             string   `json:"verb"`
+		AuthType         string   `json:"authType,omitempty"`
+		ThrottlingPolicy string   `json:"throttlingPolicy,omitempty"`
+		Scopes           []string `json:"scopes,omitempty"`
+	}
+)
+
+// NewAPIProduct creates an API Product with the default values.
+func (c *Client) NewAPIProduct() *APIProductDetail {
+	return &APIProductDetail{
+		APIProduct: APIProduct{
+			Name:     "", // required
+			Context:  "", // required
+			Provider: c.config.UserName,
+		},
+		Visibility:          APIVisibilityPublic,
+		VisibleRoles:        []string{},
+		GatewayEnvironments: []string{"Production and Sandbox"},
+		Transport:           []APITransport{APITransportHTTP, APITransportHTTPS},
+		Tags:                []string{},
+		Policies:            []string{"Unlimited"},
+		APIs:                []ProductAPI{}, // required
+	}
+}
+
+// NewProductAPI creates the ProductAPI of the API.
+// If no operation is specified, all the resources of the API are included.
+func (c *Client) NewProductAPI(apiID string, operations ...APIOperation) (*ProductAPI, error) {
+	if err := c.checkAPIProductsSupported(); err != nil {
+		return nil, err
+	}
+	api, err := c.API(apiID)
+	if err != nil {
+		return nil, err
+	}
+	resources, err := c.APIResources(apiID)
+	if err != nil {
+		return nil, err
+	}
+	if len(operations) == 0 {
+		for _, r := range resources {
+			operations = append(operations, APIOperation{
+				Target:           r.Path,
+				Verb:             r.Verb,
+				AuthType:         r.AuthType,
+				ThrottlingPolicy: r.ThrottlingTier,
+			})
+		}
+	} else {
+		for i, op := range operations {
+			operations[i].Verb = strings.ToUpper(op.Verb)
+			found := false
+			for _, r := range resources {
+				if r.Verb == operations[i].Verb && r.Path == op.Target {
+					found = true
+					break
+				}
+			}
+			if !found {
+				return nil, fmt.Errorf("resource not found in API %s: %s %s", apiID, operations[i].Verb, op.Target)
+			}
+		}
+	}
+	return &ProductAPI{
+		APIID:      apiID,
+		Name:       api.Name,
+		Version:    api.Version,
+		Operations: operations,
+	}, nil
+}
+
+func (c *Client) SearchAPIProducts(query string, productc chan<- APIProduct, errc chan<- error, done <-chan struct{}) {
+	var entryc = make(chan interface{})
+	go func() {
+		defer close(entryc)
+		c.SearchAPIProductsRaw(query, entryc, errc, done)
+	}()
+	for v := range entryc {
+		productc <- *c.ConvertToAPIProduct(v)
+	}
+}
+
+func (c *Client) ConvertToAPIProduct(v interface{}) *APIProduct {
+	var p APIProduct
+	convert(v, &p)
+	return &p
+}
+
+func (c *Client) SearchAPIProductsRaw(query string, entryc chan<- interface{}, errc chan<- error, done <-chan struct{}) {
+	c.search(entryc, errc, done, func(q *PageQuery) (*PageResponse, error) {
+		if err := c.checkAPIProductsSupported(); err != nil {
+			return nil, err
+		}
+		params := pageQueryParams(q)
+		if query != "" {
+			params.Add("query", query)
+		}
+		var v PageResponse
+		if err := c.get(c.publisherURL("api-products?"+params.Encode()), "apim:api_view", &v); err != nil {
+			return nil, err
+		}
+		return &v, nil
+	})
+}
+
+func (c *Client) APIProduct(id string) (*APIProductDetail, error) {
+	if err := c.checkAPIProductsSupported(); err != nil {
+		return nil, err
+	}
+	var v APIProductDetail
+	if err := c.get(c.publisherURL("api-products/"+id), "apim:api_view", &v); err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
+
+func (c *Client) CreateAPIProduct(product *APIProductDetail) (*APIProductDetail, error) {
+	if err := c.checkAPIProductsSupported(); err != nil {
+		return nil, err
+	}
+	var v APIProductDetail
+	if err := c.post(c.publisherURL("api-products"), "apim:api_publish", newJSONRequestBody(product), &v); err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
+
+func (c *Client) UpdateAPIProduct(product *APIProductDetail) (*APIProductDetail, error) {
+	if err := c.checkAPIProductsSupported(); err != nil {
+		return nil, err
+	}
+	var v APIProductDetail
+	if err := c.put(c.publisherURL("api-products/"+product.ID), "apim:api_publish", newJSONRequestBody(product), &v); err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
+
+func (c *Client) DeleteAPIProduct(id string) error {
+	if err := c.checkAPIProductsSupported(); err != nil {
+		return err
+	}
+	return c.delete(c.publisherURL("api-products/"+id), "apim:api_publish", nil)
+}
+
+// APIProductDefinition returns the API definition generated from the operations of the API Product.
+func (c *Client) APIProductDefinition(id string) (map[string]interface{}, error) {
+	if err := c.checkAPIProductsSupported(); err != nil {
+		return nil, err
+	}
+	var v map[string]interface{}
+	if err := c.get(c.publisherURL("api-products/"+id+"/swagger"), "apim:api_view", &v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+func (c *Client) ChangeAPIProductStatus(id string, action APIAction) error {
+	if err := c.checkAPIProductsSupported(); err != nil {
+		return err
+	}
+	params := url.Values{}
+	params.Add("apiProductId", id)
+	params.Add("action", string(action))
+	return c.post(c.publisherURL("api-products/change-lifecycle?"+params.Encode()), "apim:api_publish", nil, nil)
+}
+
+// UploadAPIProductThumbnail validates the image and uploads it as the thumbnail of the API Product.
+func (c *Client) UploadAPIProductThumbnail(id string, img *ThumbnailImage) (*APIUploadThumbnailResponse, error) {
+	if err := c.checkAPIProductsSupported(); err != nil {
+		return nil, err
+	}
+	if err := img.Validate(); err != nil {
+		return nil, err
+	}
+	body, err := newThumbnailRequestBody(img)
+	if err != nil {
+		return nil, err
+	}
+	var v APIUploadThumbnailResponse
+	if err := c.put(c.publisherURL("api-products/"+id+"/thumbnail"), "apim:api_publish", body, &v); err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
+
+// APIProductThumbnail downloads the thumbnail of the API Product to the writer, and returns its media type.
+func (c *Client) APIProductThumbnail(id string, thumbnail io.Writer) (string, error) {
+	if err := c.checkAPIProductsSupported(); err != nil {
+		return "", err
+	}
+	return c.downloadThumbnail(c.publisherURL("api-products/"+id+"/thumbnail"), "apim:api_view", thumbnail)
+}
+
+func (c *Client) checkAPIProductsSupported() error {
+	if strings.HasPrefix(c.config.APIVersion, "v0.") {
+		return fmt.Errorf("API Products are not supported by the publisher API %s; use v1 or later", c.config.APIVersion)
+	}
+	return nil
+}
@@ -0,0 +1,199 @@
+package wso2am
+
+import (
+	"bytes"
+	"encoding/json"
+	"mime/multipart"
+	"strings"
+)
+
+// apiDetailV1 is the API of the publisher API v1 or later.
+// Unlike APIDetail, the endpoint config is an object and the gateway environments are an array.
+// It is used by the v1 only resources, e.g. the import of the GraphQL schema and the WSDL.
+// https://github.com/wso2/carbon-apimgt/blob/v6.7.206/components/apimgt/org.wso2.carbon.apimgt.rest.api.publisher.v1/src/gen/java/org/wso2/carbon/apimgt/rest/api/publisher/v1/dto/APIDTO.java
+type apiDetailV1 struct {
+	ID                           string                  `json:"id,omitempty"`
+	Name                         string                  `json:"name"`
+	Description                  string                  `json:"description,omitempty"`
+	Context                      string                  `json:"context"`
+	Version                      string                  `json:"version"`
+	Provider                     string                  `json:"provider,omitempty"`
+	LifeCycleStatus              string                  `json:"lifeCycleStatus,omitempty"`
+	WSDLInfo                     interface{}             `json:"wsdlInfo,omitempty"`
+	WSDLURL                      string                  `json:"wsdlUrl,omitempty"`
+	ResponseCachingEnabled       bool                    `json:"responseCachingEnabled"`
+	CacheTimeout                 int                     `json:"cacheTimeout"`
+	DestinationStatsEnabled      bool                    `json:"destinationStatsEnabled,omitempty"`
+	HasThumbnail                 bool                    `json:"hasThumbnail,omitempty"`
+	DefaultVersion               bool                    `json:"isDefaultVersion"`
+	EnableSchemaValidation       bool                    `json:"enableSchemaValidation,omitempty"`
+	Type                         APIType                 `json:"type"`
+	Transport                    []APITransport          `json:"transport"`
+	Tags                         []string                `json:"tags"`
+	Policies                     []string                `json:"policies"`
+	APIThrottlingPolicy          string                  `json:"apiThrottlingPolicy,omitempty"`
+	AuthorizationHeader          string                  `json:"authorizationHeader,omitempty"`
+	SecurityScheme               []string                `json:"securityScheme,omitempty"`
+	MaxTPS                       *APIMaxTPS              `json:"maxTps,omitempty"`
+	Visibility                   APIVisibility           `json:"visibility"`
+	VisibleRoles                 []string                `json:"visibleRoles"`
+	VisibleTenants               []string                `json:"visibleTenants,omitempty"`
+	EndpointSecurity             *APIEndpointSecurity    `json:"endpointSecurity,omitempty"`
+	GatewayEnvironments          []string                `json:"gatewayEnvironments"`
+	Labels                       []Label                 `json:"labels,omitempty"`
+	MediationPolicies            []interface{}           `json:"mediationPolicies,omitempty"`
+	SubscriptionAvailability     *string                 `json:"subscriptionAvailability,omitempty"`
+	SubscriptionAvailableTenants []string                `json:"subscriptionAvailableTenants,omitempty"`
+	AdditionalProperties         map[string]string       `json:"additionalProperties,omitempty"`
+	AccessControl                string                  `json:"accessControl,omitempty"`
+	AccessControlRoles           []string                `json:"accessControlRoles,omitempty"`
+	BusinessInformation          *APIBusinessInformation `json:"businessInformation,omitempty"`
+	CORSConfiguration            *APICORSConfiguration   `json:"corsConfiguration,omitempty"`
+	WorkflowStatus               string                  `json:"workflowStatus,omitempty"`
+	CreatedTime                  string                  `json:"createdTime,omitempty"`
+	LastUpdatedTime              string                  `json:"lastUpdatedTime,omitempty"`
+	EndpointConfig               interface{}             `json:"endpointConfig,omitempty"`
+	EndpointImplementationType   string                  `json:"endpointImplementationType,omitempty"`
+	Scopes                       []interface{}           `json:"scopes,omitempty"`
+	Operations                   []APIOperation          `json:"operations,omitempty"`
+	ThreatProtectionPolicies     interface{}             `json:"threatProtectionPolicies,omitempty"`
+	Categories                   []string                `json:"categories,omitempty"`
+	KeyManagers                  []interface{}           `json:"keyManagers,omitempty"`
+}
+
+// newAPIDetailV1 converts the API to the model of the publisher API v1.
+func newAPIDetailV1(api *APIDetail) (*apiDetailV1, error) {
+	v := &apiDetailV1{
+		ID:                           api.ID,
+		Name:                         api.Name,
+		Description:                  api.Description,
+		Context:                      api.Context,
+		Version:                      api.Version,
+		Provider:                     api.Provider,
+		ResponseCachingEnabled:       api.ResponseCaching == "Enabled",
+		CacheTimeout:                 api.CacheTimeout,
+		DestinationStatsEnabled:      api.DestinationStatsEnabled,
+		DefaultVersion:               api.DefaultVersion,
+		Type:                         api.Type,
+		Transport:                    api.Transport,
+		Tags:                         api.Tags,
+		Policies:                     api.Tiers,
+		MaxTPS:                       api.MaxTPS,
+		Visibility:                   api.Visibility,
+		VisibleRoles:                 api.VisibleRoles,
+		EndpointSecurity:             api.EndpointSecurity,
+		GatewayEnvironments:          []string{},
+		Labels:                       api.Labels,
+		SubscriptionAvailability:     api.SubscriptionAvailability,
+		SubscriptionAvailableTenants: api.SubscriptionAvailableTenants,
+		BusinessInformation:          api.BusinessInformation,
+		CORSConfiguration:            api.CORSConfiguration,
+		Operations:                   api.Operations,
+	}
+	if api.WSDLURI != nil {
+		v.WSDLURL = *api.WSDLURI
+	}
+	for _, env := range strings.Split(api.GatewayEnvironments, ",") {
+		if env = strings.TrimSpace(env); env != "" {
+			v.GatewayEnvironments = append(v.GatewayEnvironments, env)
+		}
+	}
+	if api.EndpointConfig != "" {
+		if err := json.Unmarshal([]byte(api.EndpointConfig), &v.EndpointConfig); err != nil {
+			return nil, err
+		}
+	}
+	return v, nil
+}
+
+// apiDetail converts the API to the model of the publisher API v0.x.
+func (v *apiDetailV1) apiDetail() (*APIDetail, error) {
+	api := &APIDetail{
+		API: API{
+			ID:          v.ID,
+			Name:        v.Name,
+			Description: v.Description,
+			Context:     v.Context,
+			Version:     v.Version,
+			Provider:    v.Provider,
+			Status:      APIStatus(v.LifeCycleStatus),
+		},
+		ResponseCaching:              "Disabled",
+		CacheTimeout:                 v.CacheTimeout,
+		DestinationStatsEnabled:      v.DestinationStatsEnabled,
+		DefaultVersion:               v.DefaultVersion,
+		Type:                         v.Type,
+		Transport:                    v.Transport,
+		Tags:                         v.Tags,
+		Tiers:                        v.Policies,
+		MaxTPS:                       v.MaxTPS,
+		Visibility:                   v.Visibility,
+		VisibleRoles:                 v.VisibleRoles,
+		EndpointSecurity:             v.EndpointSecurity,
+		GatewayEnvironments:          strings.Join(v.GatewayEnvironments, ","),
+		SubscriptionAvailability:     v.SubscriptionAvailability,
+		SubscriptionAvailableTenants: v.SubscriptionAvailableTenants,
+		BusinessInformation:          v.BusinessInformation,
+		CORSConfiguration:            v.CORSConfiguration,
+		Operations:                   v.Operations,
+		Labels:                       v.Labels,
+	}
+	if v.ResponseCachingEnabled {
+		api.ResponseCaching = "Enabled"
+	}
+	if v.WSDLURL != "" {
+		uri := v.WSDLURL
+		api.WSDLURI = &uri
+	}
+	if v.EndpointConfig != nil {
+		data, err := json.Marshal(v.EndpointConfig)
+		if err != nil {
+			return nil, err
+		}
+		api.EndpointConfig = string(data)
+	}
+	return api, nil
+}
+
+// apiV1 returns the API in the model of the publisher API v1.
+func (c *Client) apiV1(id string) (*apiDetailV1, error) {
+	var v apiDetailV1
+	if err := c.get(c.publisherURL("apis/"+id), "apim:api_view", &v); err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
+
+// updateAPIV1 updates the API in the model of the publisher API v1.
+func (c *Client) updateAPIV1(api *apiDetailV1) error {
+	return c.put(c.publisherURL("apis/"+api.ID), "apim:api_create", newJSONRequestBody(api), nil)
+}
+
+// importAPIV1 creates the API with the import resource of the publisher API v1, e.g. apis/import-wsdl.
+// writeFields writes the fields of the imported file, and the API is sent as the additional properties.
+func (c *Client) importAPIV1(resource string, api *APIDetail, writeFields func(*multipart.Writer) error) (*APIDetail, error) {
+	v1, err := newAPIDetailV1(api)
+	if err != nil {
+		return nil, err
+	}
+	props, err := json.Marshal(v1)
+	if err != nil {
+		return nil, err
+	}
+	buf := new(bytes.Buffer)
+	writer := multipart.NewWriter(buf)
+	if err := writeFields(writer); err != nil {
+		return nil, err
+	}
+	if err := writer.WriteField("additionalProperties", string(props)); err != nil {
+		return nil, err
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+	var v apiDetailV1
+	if err := c.post(c.publisherURL(resource), "apim:api_create", newBinaryRequestBody(buf.Bytes(), writer.FormDataContentType()), &v); err != nil {
+		return nil, err
+	}
+	return v.apiDetail()
+}
@@ -0,0 +1,53 @@
+package wso2am
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestAPIDetailV1(t *testing.T) {
+	api := (&Client{config: &Config{UserName: "admin"}}).NewAPI()
+	api.Name = "pizza"
+	api.GatewayEnvironments = "Production and Sandbox, Internal"
+	api.SetEndpointConfig(&APIEndpointConfig{Type: "http", ProductionEndpoints: &APIEndpoint{URL: "http://localhost:8080"}})
+
+	v1, err := newAPIDetailV1(api)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	data, err := json.Marshal(v1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var m map[string]interface{}
+	if err := json.Unmarshal(data, &m); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := []interface{}{"Production and Sandbox", "Internal"}; !reflect.DeepEqual(m["gatewayEnvironments"], want) {
+		t.Errorf("gatewayEnvironments = %v, want %v", m["gatewayEnvironments"], want)
+	}
+	if _, ok := m["endpointConfig"].(map[string]interface{}); !ok {
+		t.Errorf("endpointConfig = %v, want an object", m["endpointConfig"])
+	}
+
+	// the response of the server has the endpoint config as an object
+	var res apiDetailV1
+	if err := json.Unmarshal(data, &res); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got, err := res.apiDetail()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.GatewayEnvironments != "Production and Sandbox,Internal" {
+		t.Errorf("GatewayEnvironments = %q", got.GatewayEnvironments)
+	}
+	var endpointConfig APIEndpointConfig
+	if err := json.Unmarshal([]byte(got.EndpointConfig), &endpointConfig); err != nil {
+		t.Fatalf("EndpointConfig = %q: %v", got.EndpointConfig, err)
+	}
+	if endpointConfig.ProductionEndpoints.URL != "http://localhost:8080" {
+		t.Errorf("EndpointConfig = %q", got.EndpointConfig)
+	}
+}
@@ -0,0 +1,383 @@
+package wso2am
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime/multipart"
+	"net/url"
+	"sort"
+	"strconv"
+	"time"
+)
+
+type (
+	// EndpointCertificate is a certificate of the backend endpoint.
+	// https://github.com/wso2/carbon-apimgt/blob/master/components/apimgt/org.wso2.carbon.apimgt.rest.api.publisher/src/gen/java/org/wso2/carbon/apimgt/rest/api/publisher/dto/CertMetadataDTO.java
+	EndpointCertificate struct {
+		Alias    string `json:"alias"`
+		Endpoint string `json:"endpoint"`
+	}
+	// ClientCertificate is a certificate of the client which is allowed to call the API by mutual SSL.
+	// https://github.com/wso2/carbon-apimgt/blob/master/components/apimgt/org.wso2.carbon.apimgt.rest.api.publisher/src/gen/java/org/wso2/carbon/apimgt/rest/api/publisher/dto/ClientCertMetadataDTO.java
+	ClientCertificate struct {
+		Alias string `json:"alias"`
+		APIID string `json:"apiId"`
+		Tier  string `json:"tier"`
+	}
+	// CertificateDetail is the detail of the certificate parsed locally.
+	CertificateDetail struct {
+		Alias        string    `json:"alias"`
+		Subject      string    `json:"subject"`
+		Issuer       string    `json:"issuer"`
+		SerialNumber string    `json:"serialNumber"`
+		NotBefore    time.Time `json:"notBefore"`
+		NotAfter     time.Time `json:"notAfter"`
+		// Fingerprint is the SHA-256 fingerprint of the DER encoded certificate.
+		Fingerprint string `json:"fingerprint"`
+	}
+	// CertificateFilter filters the certificates on the server side.  Empty fields are ignored.
+	CertificateFilter struct {
+		Alias    string
+		Endpoint string
+		APIID    string
+	}
+	// CertificateReportEntry is an entry of the certificate expiry report.
+	CertificateReportEntry struct {
+		CertificateDetail
+		Kind     CertificateKind `json:"kind"`
+		Endpoint string          `json:"endpoint,omitempty"`
+		APIID    string          `json:"apiId,omitempty"`
+		Tier     string          `json:"tier,omitempty"`
+		// Error is the reason why the certificate could not be checked.
+		Error string `json:"error,omitempty"`
+	}
+	// CertificateKind is the kind of the certificate.
+	CertificateKind string
+)
+
+const (
+	CertificateKindEndpoint CertificateKind = "endpoint"
+	CertificateKindClient   CertificateKind = "client"
+)
+
+// ExpiresWithin returns true if the certificate expires within the duration from now.
+func (d *CertificateDetail) ExpiresWithin(duration time.Duration) bool {
+	return time.Now().Add(duration).After(d.NotAfter)
+}
+
+// ParseCertificate parses the PEM or DER encoded X.509 certificate.
+func ParseCertificate(data []byte) (*CertificateDetail, error) {
+	der := data
+	if block, _ := pem.Decode(data); block != nil {
+		if block.Type != "CERTIFICATE" {
+			return nil, fmt.Errorf("unexpected PEM block: %s", block.Type)
+		}
+		der = block.Bytes
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, err
+	}
+	fingerprint := sha256.Sum256(cert.Raw)
+	return &CertificateDetail{
+		Subject:      cert.Subject.String(),
+		Issuer:       cert.Issuer.String(),
+		SerialNumber: cert.SerialNumber.String(),
+		NotBefore:    cert.NotBefore,
+		NotAfter:     cert.NotAfter,
+		Fingerprint:  hex.EncodeToString(fingerprint[:]),
+	}, nil
+}
+
+// EndpointCertificates lists the endpoint certificates.
+func (c *Client) EndpointCertificates(filter CertificateFilter) ([]EndpointCertificate, error) {
+	result := []EndpointCertificate{}
+	err := c.listCertificates("certificates", "apim:ep_certificates_view", filter, func(v interface{}) error {
+		var certs []EndpointCertificate
+		if err := convert(v, &certs); err != nil {
+			return err
+		}
+		result = append(result, certs...)
+		return nil
+	})
+	return result, err
+}
+
+// ClientCertificates lists the client certificates.
+func (c *Client) ClientCertificates(filter CertificateFilter) ([]ClientCertificate, error) {
+	result := []ClientCertificate{}
+	err := c.listCertificates("clientCertificates", "apim:client_certificates_view", filter, func(v interface{}) error {
+		var certs []ClientCertificate
+		if err := convert(v, &certs); err != nil {
+			return err
+		}
+		result = append(result, certs...)
+		return nil
+	})
+	return result, err
+}
+
+func (c *Client) listCertificates(path, scope string, filter CertificateFilter, f func(interface{}) error) error {
+	params := url.Values{}
+	if filter.Alias != "" {
+		params.Add("alias", filter.Alias)
+	}
+	if filter.Endpoint != "" {
+		params.Add("endpoint", filter.Endpoint)
+	}
+	if filter.APIID != "" {
+		params.Add("apiId", filter.APIID)
+	}
+	offset, limit := 0, 100
+	for {
+		params.Set("offset", strconv.Itoa(offset))
+		params.Set("limit", strconv.Itoa(limit))
+		var v struct {
+			Count        int         `json:"count"`
+			Certificates interface{} `json:"certificates"`
+		}
+		if err := c.get(c.publisherURL(path+"?"+params.Encode()), scope, &v); err != nil {
+			return err
+		}
+		if v.Count == 0 {
+			return nil
+		}
+		if err := f(v.Certificates); err != nil {
+			return err
+		}
+		if v.Count < limit {
+			return nil
+		}
+		offset += v.Count
+	}
+}
+
+// AddEndpointCertificate uploads the certificate of the endpoint.
+// The certificate is parsed locally before the upload.
+func (c *Client) AddEndpointCertificate(cert EndpointCertificate, certificate []byte) (*EndpointCertificate, error) {
+	if cert.Alias == "" || cert.Endpoint == "" {
+		return nil, errors.New("alias and endpoint are required")
+	}
+	body, err := newCertificateRequestBody(certificate, map[string]string{
+		"alias":    cert.Alias,
+		"endpoint": cert.Endpoint,
+	})
+	if err != nil {
+		return nil, err
+	}
+	var v EndpointCertificate
+	if err := c.post(c.publisherURL("certificates"), "apim:ep_certificates_add", body, &v); err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
+
+// UpdateEndpointCertificate replaces the certificate of the alias.
+func (c *Client) UpdateEndpointCertificate(alias string, certificate []byte) (*EndpointCertificate, error) {
+	body, err := newCertificateRequestBody(certificate, nil)
+	if err != nil {
+		return nil, err
+	}
+	var v EndpointCertificate
+	if err := c.put(c.publisherURL("certificates/"+url.PathEscape(alias)), "apim:ep_certificates_update", body, &v); err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
+
+// DeleteEndpointCertificate deletes the endpoint certificate of the alias.
+func (c *Client) DeleteEndpointCertificate(alias string) error {
+	return c.delete(c.publisherURL("certificates/"+url.PathEscape(alias)), "apim:ep_certificates_update", nil)
+}
+
+// EndpointCertificateContent returns the content of the endpoint certificate.
+func (c *Client) EndpointCertificateContent(alias string) ([]byte, error) {
+	buf := new(bytes.Buffer)
+	if err := c.get(c.publisherURL("certificates/"+url.PathEscape(alias)+"/content"), "apim:ep_certificates_view", buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// EndpointCertificateDetail downloads the endpoint certificate and parses it.
+func (c *Client) EndpointCertificateDetail(alias string) (*CertificateDetail, error) {
+	data, err := c.EndpointCertificateContent(alias)
+	if err != nil {
+		return nil, err
+	}
+	return parseCertificateOf(alias, data)
+}
+
+// AddClientCertificate uploads the client certificate of the API.
+// The certificate is parsed locally before the upload.
+func (c *Client) AddClientCertificate(cert ClientCertificate, certificate []byte) (*ClientCertificate, error) {
+	if cert.Alias == "" || cert.APIID == "" || cert.Tier == "" {
+		return nil, errors.New("alias, API ID and tier are required")
+	}
+	body, err := newCertificateRequestBody(certificate, map[string]string{
+		"alias": cert.Alias,
+		"apiId": cert.APIID,
+		"tier":  cert.Tier,
+	})
+	if err != nil {
+		return nil, err
+	}
+	var v ClientCertificate
+	if err := c.post(c.publisherURL("clientCertificates"), "apim:client_certificates_add", body, &v); err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
+
+// UpdateClientCertificate updates the certificate and/or the tier of the alias.
+// The certificate is not changed if it is nil, and the tier is not changed if it is empty.
+func (c *Client) UpdateClientCertificate(alias string, certificate []byte, tier string) (*ClientCertificate, error) {
+	fields := map[string]string{}
+	if tier != "" {
+		fields["tier"] = tier
+	}
+	body, err := newCertificateRequestBody(certificate, fields)
+	if err != nil {
+		return nil, err
+	}
+	var v ClientCertificate
+	if err := c.put(c.publisherURL("clientCertificates/"+url.PathEscape(alias)), "apim:client_certificates_update", body, &v); err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
+
+// DeleteClientCertificate deletes the client certificate of the alias.
+func (c *Client) DeleteClientCertificate(alias string) error {
+	return c.delete(c.publisherURL("clientCertificates/"+url.PathEscape(alias)), "apim:client_certificates_update", nil)
+}
+
+// ClientCertificateContent returns the content of the client certificate.
+func (c *Client) ClientCertificateContent(alias string) ([]byte, error) {
+	buf := new(bytes.Buffer)
+	if err := c.get(c.publisherURL("clientCertificates/"+url.PathEscape(alias)+"/content"), "apim:client_certificates_view", buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// ClientCertificateDetail downloads the client certificate and parses it.
+func (c *Client) ClientCertificateDetail(alias string) (*CertificateDetail, error) {
+	data, err := c.ClientCertificateContent(alias)
+	if err != nil {
+		return nil, err
+	}
+	return parseCertificateOf(alias, data)
+}
+
+func parseCertificateOf(alias string, data []byte) (*CertificateDetail, error) {
+	detail, err := ParseCertificate(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the certificate %s: %v", alias, err)
+	}
+	detail.Alias = alias
+	return detail, nil
+}
+
+// newCertificateRequestBody creates the multipart body which has the certificate file and the fields.
+// The certificate is omitted if it is nil.
+func newCertificateRequestBody(certificate []byte, fields map[string]string) (requestBody, error) {
+	buf := new(bytes.Buffer)
+	writer := multipart.NewWriter(buf)
+	if certificate != nil {
+		if _, err := ParseCertificate(certificate); err != nil {
+			return nil, fmt.Errorf("invalid certificate: %v", err)
+		}
+		w, err := writer.CreateFormFile("certificate", "certificate.crt")
+		if err != nil {
+			return nil, err
+		}
+		if _, err := io.Copy(w, bytes.NewReader(certificate)); err != nil {
+			return nil, err
+		}
+	}
+	keys := []string{}
+	for k := range fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		if err := writer.WriteField(k, fields[k]); err != nil {
+			return nil, err
+		}
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+	return newBinaryRequestBody(buf.Bytes(), writer.FormDataContentType()), nil
+}
+
+// ReadCertificateFile reads and parses the certificate file.
+func ReadCertificateFile(path string) ([]byte, *CertificateDetail, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	detail, err := ParseCertificate(data)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid certificate %s: %v", path, err)
+	}
+	return data, detail, nil
+}
+
+// ExpiringCertificates returns the endpoint and client certificates which expire within the duration,
+// sorted by the expiry.  The expired certificates are also included.
+// The certificates which cannot be downloaded or parsed are reported with the error, and sorted first.
+func (c *Client) ExpiringCertificates(within time.Duration) ([]CertificateReportEntry, error) {
+	report := []CertificateReportEntry{}
+	endpointCerts, err := c.EndpointCertificates(CertificateFilter{})
+	if err != nil {
+		return nil, err
+	}
+	for _, cert := range endpointCerts {
+		entry := CertificateReportEntry{
+			Kind:     CertificateKindEndpoint,
+			Endpoint: cert.Endpoint,
+		}
+		report = appendCertificateReportEntry(report, entry, cert.Alias, within, c.EndpointCertificateDetail)
+	}
+	clientCerts, err := c.ClientCertificates(CertificateFilter{})
+	if err != nil {
+		return nil, err
+	}
+	for _, cert := range clientCerts {
+		entry := CertificateReportEntry{
+			Kind:  CertificateKindClient,
+			APIID: cert.APIID,
+			Tier:  cert.Tier,
+		}
+		report = appendCertificateReportEntry(report, entry, cert.Alias, within, c.ClientCertificateDetail)
+	}
+	sort.SliceStable(report, func(i, j int) bool {
+		return report[i].NotAfter.Before(report[j].NotAfter)
+	})
+	return report, nil
+}
+
+// appendCertificateReportEntry appends the entry of the certificate if it expires within the duration,
+// or if its detail cannot be retrieved.
+func appendCertificateReportEntry(report []CertificateReportEntry, entry CertificateReportEntry, alias string, within time.Duration, detail func(alias string) (*CertificateDetail, error)) []CertificateReportEntry {
+	d, err := detail(alias)
+	if err != nil {
+		entry.Alias = alias
+		entry.Error = err.Error()
+		return append(report, entry)
+	}
+	if !d.ExpiresWithin(within) {
+		return report
+	}
+	entry.CertificateDetail = *d
+	return append(report, entry)
+}
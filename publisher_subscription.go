@@ -19,6 +19,10 @@ type (
 const (
 	SubscriptionBlockStateBlocked         SubscriptionBlockState = "BLOCKED"
 	SubscriptionBlockStateProdOnlyBlocked SubscriptionBlockState = "PROD_ONLY_BLOCKED"
+
+	// SubscriptionStatusUnblocked is the status of the active subscriptions.
+	// The blocked subscriptions have the SubscriptionBlockState as the status.
+	SubscriptionStatusUnblocked = "UNBLOCKED"
 )
 
 func (a *SubscriptionResponse) Subscriptions() []Subscription {
@@ -0,0 +1,195 @@
+package wso2am
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+type (
+	// Tier is a throttling tier.
+	// https://github.com/wso2/carbon-apimgt/blob/master/components/apimgt/org.wso2.carbon.apimgt.rest.api.publisher/src/gen/java/org/wso2/carbon/apimgt/rest/api/publisher/dto/TierDTO.java
+	Tier struct {
+		Name             string            `json:"name"`
+		Description      string            `json:"description"`
+		TierLevel        TierLevel         `json:"tierLevel"`
+		Attributes       map[string]string `json:"attributes"`
+		RequestCount     int64             `json:"requestCount"`
+		UnitTime         int64             `json:"unitTime"`
+		TimeUnit         string            `json:"timeUnit"`
+		TierPlan         string            `json:"tierPlan"`
+		StopOnQuotaReach bool              `json:"stopOnQuotaReach"`
+	}
+	// Environment is a gateway environment.
+	// https://github.com/wso2/carbon-apimgt/blob/master/components/apimgt/org.wso2.carbon.apimgt.rest.api.publisher/src/gen/java/org/wso2/carbon/apimgt/rest/api/publisher/dto/EnvironmentDTO.java
+	Environment struct {
+		Name             string               `json:"name"`
+		Type             string               `json:"type"`
+		ServerURL        string               `json:"serverUrl"`
+		ShowInAPIConsole bool                 `json:"showInApiConsole"`
+		Endpoints        EnvironmentEndpoints `json:"endpoints"`
+	}
+	EnvironmentEndpoints struct {
+		HTTP  string `json:"http"`
+		HTTPS string `json:"https"`
+	}
+	TierLevel string
+)
+
+const (
+	// TierLevelAPI is the level of the subscription tiers of the APIs.
+	TierLevelAPI         TierLevel = "api"
+	TierLevelApplication TierLevel = "application"
+	TierLevelResource    TierLevel = "resource"
+)
+
+// TierLevels are the available tier levels.
+var TierLevels = []TierLevel{TierLevelAPI, TierLevelApplication, TierLevelResource}
+
+// ParseTierLevel parses the tier level case-insensitively.
+func ParseTierLevel(s string) (TierLevel, error) {
+	names := []string{}
+	for _, l := range TierLevels {
+		if strings.EqualFold(string(l), s) {
+			return l, nil
+		}
+		names = append(names, string(l))
+	}
+	return "", fmt.Errorf("unsupported tier level: %s (available: %s)", s, strings.Join(names, ", "))
+}
+
+// Tiers returns the throttling tiers of the level.
+func (c *Client) Tiers(level TierLevel) ([]Tier, error) {
+	result, err := c.SearchResultToSlice(func(entryc chan<- interface{}, errc chan<- error, done <-chan struct{}) {
+		c.search(entryc, errc, done, func(q *PageQuery) (*PageResponse, error) {
+			params := pageQueryParams(q)
+			var v PageResponse
+			if err := c.get(c.publisherURL("tiers/"+url.PathEscape(string(level))+"?"+params.Encode()), "apim:tier_view", &v); err != nil {
+				return nil, err
+			}
+			return &v, nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	var tiers []Tier
+	if err := convert(result, &tiers); err != nil {
+		return nil, err
+	}
+	return tiers, nil
+}
+
+// Environments returns the gateway environments.
+func (c *Client) Environments() ([]Environment, error) {
+	var v struct {
+		Count int           `json:"count"`
+		List  []Environment `json:"list"`
+	}
+	if err := c.get(c.publisherURL("environments"), "apim:api_view", &v); err != nil {
+		return nil, err
+	}
+	return v.List, nil
+}
+
+// ValidateTiers checks the tiers exist in the level.
+// The error suggests the close matches of the unknown tiers.
+func (c *Client) ValidateTiers(level TierLevel, names []string) error {
+	tiers, err := c.Tiers(level)
+	if err != nil {
+		return err
+	}
+	available := []string{}
+	for _, t := range tiers {
+		available = append(available, t.Name)
+	}
+	for _, name := range names {
+		if err := checkAvailable(fmt.Sprintf("%s tier", level), name, available); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ValidateGatewayEnvironments checks the environments exist.
+// The environments are comma separated as the GatewayEnvironments of the APIDetail.
+func (c *Client) ValidateGatewayEnvironments(environments string) error {
+	envs, err := c.Environments()
+	if err != nil {
+		return err
+	}
+	available := []string{}
+	for _, e := range envs {
+		available = append(available, e.Name)
+	}
+	for _, name := range strings.Split(environments, ",") {
+		if err := checkAvailable("gateway environment", strings.TrimSpace(name), available); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkAvailable returns the error with the suggestions if the name is not in the available names.
+func checkAvailable(kind, name string, available []string) error {
+	if containsString(available, name) {
+		return nil
+	}
+	msg := fmt.Sprintf("unknown %s: %q", kind, name)
+	if suggestions := suggest(name, available); len(suggestions) > 0 {
+		msg += fmt.Sprintf("; did you mean %q?", strings.Join(suggestions, `" or "`))
+	} else if len(available) > 0 {
+		msg += fmt.Sprintf(" (available: %s)", strings.Join(available, ", "))
+	}
+	return fmt.Errorf("%s", msg)
+}
+
+// suggest returns the candidates which are close to the name.
+// The candidate is close if it differs only in case, or the edit distance is at most 2 or a third of its length.
+func suggest(name string, candidates []string) []string {
+	result := []string{}
+	for _, c := range candidates {
+		if strings.EqualFold(c, name) {
+			return []string{c}
+		}
+	}
+	for _, c := range candidates {
+		d := editDistance(strings.ToLower(name), strings.ToLower(c))
+		if d <= 2 || d <= len(c)/3 {
+			result = append(result, c)
+		}
+	}
+	return result
+}
+
+// editDistance returns the Levenshtein distance of the strings.
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	cur := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		cur[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			cur[j] = minInt(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(rb)]
+}
+
+func minInt(values ...int) int {
+	m := values[0]
+	for _, v := range values[1:] {
+		if v < m {
+			m = v
+		}
+	}
+	return m
+}
@@ -0,0 +1,242 @@
+package wso2am
+
+import (
+	"io"
+)
+
+type (
+	// StoreAPI is a published API in the store (developer portal).
+	StoreAPI struct {
+		ID           string `json:"id"`
+		Name         string `json:"name"`
+		Description  string `json:"description"`
+		Context      string `json:"context"`
+		Version      string `json:"version"`
+		Provider     string `json:"provider"`
+		Status       string `json:"status"`
+		ThumbnailURI string `json:"thumbnailUri"`
+	}
+	// https://github.com/wso2/carbon-apimgt/blob/master/components/apimgt/org.wso2.carbon.apimgt.rest.api.store/src/gen/java/org/wso2/carbon/apimgt/rest/api/store/dto/APIDTO.java
+	StoreAPIDetail struct {
+		StoreAPI
+		Definition          APIDefinition           `json:"apiDefinition"`
+		WSDLURI             string                  `json:"wsdlUri,omitempty"`
+		DefaultVersion      bool                    `json:"isDefaultVersion"`
+		Transport           []APITransport          `json:"transport"`
+		Tags                []string                `json:"tags"`
+		Tiers               []string                `json:"tiers"`
+		EndpointURLs        []StoreAPIEndpointURL   `json:"endpointURLs"`
+		BusinessInformation *APIBusinessInformation `json:"businessInformation"`
+		Labels              []Label                 `json:"labels,omitempty"`
+	}
+	StoreAPIEndpointURL struct {
+		EnvironmentName string `json:"environmentName"`
+		EnvironmentType string `json:"environmentType"`
+		EnvironmentURLs struct {
+			HTTP  string `json:"http"`
+			HTTPS string `json:"https"`
+		} `json:"environmentURLs"`
+	}
+	// https://github.com/wso2/carbon-apimgt/blob/master/components/apimgt/org.wso2.carbon.apimgt.rest.api.store/src/gen/java/org/wso2/carbon/apimgt/rest/api/store/dto/DocumentDTO.java
+	Document struct {
+		ID         string `json:"documentId"`
+		Name       string `json:"name"`
+		Type       string `json:"type"`
+		Summary    string `json:"summary"`
+		SourceType string `json:"sourceType"`
+		SourceURL  string `json:"sourceUrl"`
+		OtherType  string `json:"otherTypeName"`
+	}
+	// Tag is a tag of the APIs with the number of the tagged APIs.
+	Tag struct {
+		Name  string `json:"name"`
+		Count int    `json:"weight"`
+	}
+	// Rating is a rating of the API by a user.
+	Rating struct {
+		ID      string `json:"ratingId"`
+		APIID   string `json:"apiId"`
+		RatedBy string `json:"ratedBy"`
+		Rating  int    `json:"rating"`
+	}
+	// Ratings are the ratings of the API.
+	Ratings struct {
+		Average    string   `json:"avgRating"`
+		UserRating int      `json:"userRating"`
+		Count      int      `json:"count"`
+		List       []Rating `json:"list"`
+	}
+	// Comment is a comment on the API.
+	Comment struct {
+		ID          string `json:"id"`
+		Content     string `json:"content"`
+		CreatedTime string `json:"createdTime"`
+		CreatedBy   string `json:"createdBy"`
+	}
+)
+
+const (
+	DocumentSourceTypeInline = "INLINE"
+	DocumentSourceTypeURL    = "URL"
+	DocumentSourceTypeFile   = "FILE"
+)
+
+func (c *Client) SearchStoreAPIs(query string, apic chan<- StoreAPI, errc chan<- error, done <-chan struct{}) {
+	var entryc = make(chan interface{})
+	go func() {
+		defer close(entryc)
+		c.SearchStoreAPIsRaw(query, entryc, errc, done)
+	}()
+	for v := range entryc {
+		apic <- *c.ConvertToStoreAPI(v)
+	}
+}
+
+func (c *Client) ConvertToStoreAPI(v interface{}) *StoreAPI {
+	var a StoreAPI
+	convert(v, &a)
+	return &a
+}
+
+// SearchStoreAPIsRaw searches the APIs published to the store.
+// The query has the same syntax as the publisher's, e.g. "name:pizza" and "tag:food".
+func (c *Client) SearchStoreAPIsRaw(query string, entryc chan<- interface{}, errc chan<- error, done <-chan struct{}) {
+	c.search(entryc, errc, done, func(q *PageQuery) (*PageResponse, error) {
+		params := pageQueryParams(q)
+		if query != "" {
+			params.Add("query", query)
+		}
+		var v PageResponse
+		if err := c.get(c.storeURL("apis?"+params.Encode()), "apim:subscribe", &v); err != nil {
+			return nil, err
+		}
+		return &v, nil
+	})
+}
+
+func (c *Client) StoreAPI(id string) (*StoreAPIDetail, error) {
+	var v StoreAPIDetail
+	if err := c.get(c.storeURL("apis/"+id), "apim:subscribe", &v); err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
+
+func (c *Client) StoreAPIDefinition(id string) (map[string]interface{}, error) {
+	var v map[string]interface{}
+	if err := c.get(c.storeURL("apis/"+id+"/swagger"), "apim:subscribe", &v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+func (c *Client) StoreDocumentsRaw(apiID string, entryc chan<- interface{}, errc chan<- error, done <-chan struct{}) {
+	c.search(entryc, errc, done, func(q *PageQuery) (*PageResponse, error) {
+		params := pageQueryParams(q)
+		var v PageResponse
+		if err := c.get(c.storeURL("apis/"+apiID+"/documents?"+params.Encode()), "apim:subscribe", &v); err != nil {
+			return nil, err
+		}
+		return &v, nil
+	})
+}
+
+func (c *Client) ConvertToDocument(v interface{}) *Document {
+	var d Document
+	convert(v, &d)
+	return &d
+}
+
+// StoreDocuments returns the documents of the API.
+func (c *Client) StoreDocuments(apiID string) ([]Document, error) {
+	result, err := c.SearchResultToSlice(func(entryc chan<- interface{}, errc chan<- error, done <-chan struct{}) {
+		c.StoreDocumentsRaw(apiID, entryc, errc, done)
+	})
+	if err != nil {
+		return nil, err
+	}
+	docs := []Document{}
+	for _, v := range result {
+		docs = append(docs, *c.ConvertToDocument(v))
+	}
+	return docs, nil
+}
+
+// StoreDocumentContent downloads the content of the INLINE or FILE document.
+func (c *Client) StoreDocumentContent(apiID, documentID string, content io.Writer) error {
+	return c.get(c.storeURL("apis/"+apiID+"/documents/"+documentID+"/content"), "apim:subscribe", content)
+}
+
+// StoreTags returns the tags of the published APIs.
+func (c *Client) StoreTags() ([]Tag, error) {
+	result, err := c.SearchResultToSlice(func(entryc chan<- interface{}, errc chan<- error, done <-chan struct{}) {
+		c.search(entryc, errc, done, func(q *PageQuery) (*PageResponse, error) {
+			var v PageResponse
+			if err := c.get(c.storeURL("tags?"+pageQueryParams(q).Encode()), "apim:subscribe", &v); err != nil {
+				return nil, err
+			}
+			return &v, nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	var tags []Tag
+	if err := convert(result, &tags); err != nil {
+		return nil, err
+	}
+	return tags, nil
+}
+
+// StoreRatings returns the ratings of the API.
+func (c *Client) StoreRatings(apiID string) (*Ratings, error) {
+	var v Ratings
+	if err := c.get(c.storeURL("apis/"+apiID+"/ratings"), "apim:subscribe", &v); err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
+
+// StoreComments returns the comments on the API.
+func (c *Client) StoreComments(apiID string) ([]Comment, error) {
+	result, err := c.SearchResultToSlice(func(entryc chan<- interface{}, errc chan<- error, done <-chan struct{}) {
+		c.search(entryc, errc, done, func(q *PageQuery) (*PageResponse, error) {
+			var v PageResponse
+			if err := c.get(c.storeURL("apis/"+apiID+"/comments?"+pageQueryParams(q).Encode()), "apim:subscribe", &v); err != nil {
+				return nil, err
+			}
+			return &v, nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	var comments []Comment
+	if err := convert(result, &comments); err != nil {
+		return nil, err
+	}
+	return comments, nil
+}
+
+// FindStoreAPI finds the published API by the exact name and the version.
+// If the version is empty, the name must identify a single API.
+// It returns nil if not found.
+func (c *Client) FindStoreAPI(name, version string) (*StoreAPI, error) {
+	result, err := c.SearchResultToSlice(func(entryc chan<- interface{}, errc chan<- error, done <-chan struct{}) {
+		c.SearchStoreAPIsRaw("name:"+name, entryc, errc, done)
+	})
+	if err != nil {
+		return nil, err
+	}
+	apis := make([]*StoreAPI, len(result))
+	for i, v := range result {
+		apis[i] = c.ConvertToStoreAPI(v)
+	}
+	i, err := findNameVersion(name, version, len(apis), func(i int) (string, string) {
+		return apis[i].Name, apis[i].Version
+	})
+	if err != nil || i < 0 {
+		return nil, err
+	}
+	return apis[i], nil
+}
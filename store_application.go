@@ -0,0 +1,112 @@
+package wso2am
+
+type (
+	// Application is a consumer application in the store.
+	// https://github.com/wso2/carbon-apimgt/blob/master/components/apimgt/org.wso2.carbon.apimgt.rest.api.store/src/gen/java/org/wso2/carbon/apimgt/rest/api/store/dto/ApplicationDTO.java
+	Application struct {
+		ID             string            `json:"applicationId,omitempty"`
+		Name           string            `json:"name"`
+		Subscriber     string            `json:"subscriber,omitempty"`
+		ThrottlingTier string            `json:"throttlingTier"`
+		CallbackURL    string            `json:"callbackUrl,omitempty"`
+		Description    string            `json:"description,omitempty"`
+		Status         ApplicationStatus `json:"status,omitempty"`
+		// GroupID is the comma separated groups which share the application.
+		GroupID    string            `json:"groupId,omitempty"`
+		Attributes map[string]string `json:"attributes,omitempty"`
+	}
+	ApplicationStatus string
+)
+
+const (
+	ApplicationStatusCreated  ApplicationStatus = "CREATED"
+	ApplicationStatusApproved ApplicationStatus = "APPROVED"
+	ApplicationStatusRejected ApplicationStatus = "REJECTED"
+)
+
+// NewApplication creates an application with the default values.
+func (c *Client) NewApplication(name string) *Application {
+	return &Application{
+		Name:           name,
+		ThrottlingTier: "Unlimited",
+		Attributes:     map[string]string{},
+	}
+}
+
+func (c *Client) SearchApplications(query string, appc chan<- Application, errc chan<- error, done <-chan struct{}) {
+	var entryc = make(chan interface{})
+	go func() {
+		defer close(entryc)
+		c.SearchApplicationsRaw(query, entryc, errc, done)
+	}()
+	for v := range entryc {
+		appc <- *c.ConvertToApplication(v)
+	}
+}
+
+func (c *Client) ConvertToApplication(v interface{}) *Application {
+	var a Application
+	convert(v, &a)
+	return &a
+}
+
+// SearchApplicationsRaw searches the applications of the user by the name.
+// The query matches the names partially.
+func (c *Client) SearchApplicationsRaw(query string, entryc chan<- interface{}, errc chan<- error, done <-chan struct{}) {
+	c.search(entryc, errc, done, func(q *PageQuery) (*PageResponse, error) {
+		params := pageQueryParams(q)
+		if query != "" {
+			params.Add("query", query)
+		}
+		var v PageResponse
+		if err := c.get(c.storeURL("applications?"+params.Encode()), "apim:subscribe", &v); err != nil {
+			return nil, err
+		}
+		return &v, nil
+	})
+}
+
+func (c *Client) Application(id string) (*Application, error) {
+	var v Application
+	if err := c.get(c.storeURL("applications/"+id), "apim:subscribe", &v); err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
+
+// ApplicationByName finds the application by the exact name.
+// It returns nil if not found.
+func (c *Client) ApplicationByName(name string) (*Application, error) {
+	result, err := c.SearchResultToSlice(func(entryc chan<- interface{}, errc chan<- error, done <-chan struct{}) {
+		c.SearchApplicationsRaw(name, entryc, errc, done)
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range result {
+		if a := c.ConvertToApplication(v); a.Name == name {
+			return c.Application(a.ID)
+		}
+	}
+	return nil, nil
+}
+
+func (c *Client) CreateApplication(app *Application) (*Application, error) {
+	var v Application
+	if err := c.post(c.storeURL("applications"), "apim:subscribe", newJSONRequestBody(app), &v); err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
+
+func (c *Client) UpdateApplication(app *Application) (*Application, error) {
+	var v Application
+	if err := c.put(c.storeURL("applications/"+app.ID), "apim:subscribe", newJSONRequestBody(app), &v); err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
+
+func (c *Client) DeleteApplication(id string) error {
+	return c.delete(c.storeURL("applications/"+id), "apim:subscribe", nil)
+}
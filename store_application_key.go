@@ -0,0 +1,171 @@
+package wso2am
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+type (
+	// ApplicationKey is the OAuth client of the application.
+	// https://github.com/wso2/carbon-apimgt/blob/master/components/apimgt/org.wso2.carbon.apimgt.rest.api.store/src/gen/java/org/wso2/carbon/apimgt/rest/api/store/dto/ApplicationKeyDTO.java
+	ApplicationKey struct {
+		ConsumerKey         string             `json:"consumerKey,omitempty"`
+		ConsumerSecret      string             `json:"consumerSecret,omitempty"`
+		SupportedGrantTypes []string           `json:"supportedGrantTypes,omitempty"`
+		CallbackURL         string             `json:"callbackUrl,omitempty"`
+		KeyState            string             `json:"keyState,omitempty"`
+		KeyType             ApplicationKeyType `json:"keyType,omitempty"`
+		Token               *ApplicationToken  `json:"token,omitempty"`
+	}
+	// ApplicationToken is the access token generated with the keys.
+	ApplicationToken struct {
+		AccessToken  string   `json:"accessToken"`
+		TokenScopes  []string `json:"tokenScopes"`
+		ValidityTime int64    `json:"validityTime"`
+	}
+	// ApplicationKeyGenerateRequest is the request to generate the keys of the application.
+	// https://github.com/wso2/carbon-apimgt/blob/master/components/apimgt/org.wso2.carbon.apimgt.rest.api.store/src/gen/java/org/wso2/carbon/apimgt/rest/api/store/dto/ApplicationKeyGenerateRequestDTO.java
+	ApplicationKeyGenerateRequest struct {
+		KeyType             ApplicationKeyType `json:"keyType"`
+		ValidityTime        string             `json:"validityTime"`
+		CallbackURL         string             `json:"callbackUrl,omitempty"`
+		AccessAllowDomains  []string           `json:"accessAllowDomains"`
+		Scopes              []string           `json:"scopes,omitempty"`
+		SupportedGrantTypes []string           `json:"supportedGrantTypes,omitempty"`
+	}
+	// ApplicationKeyMappingRequest is the request to map the existing OAuth client to the application.
+	ApplicationKeyMappingRequest struct {
+		ConsumerKey    string             `json:"consumerKey"`
+		ConsumerSecret string             `json:"consumerSecret"`
+		KeyType        ApplicationKeyType `json:"keyType"`
+	}
+	ApplicationKeyType string
+)
+
+const (
+	ApplicationKeyTypeProduction ApplicationKeyType = "PRODUCTION"
+	ApplicationKeyTypeSandbox    ApplicationKeyType = "SANDBOX"
+)
+
+// ApplicationKeyTypes are the available key types.
+var ApplicationKeyTypes = []ApplicationKeyType{ApplicationKeyTypeProduction, ApplicationKeyTypeSandbox}
+
+// ParseApplicationKeyType parses the key type case-insensitively.
+func ParseApplicationKeyType(s string) (ApplicationKeyType, error) {
+	names := []string{}
+	for _, t := range ApplicationKeyTypes {
+		if strings.EqualFold(string(t), s) {
+			return t, nil
+		}
+		names = append(names, string(t))
+	}
+	return "", fmt.Errorf("unsupported key type: %s (available: %s)", s, strings.Join(names, ", "))
+}
+
+// NewApplicationKeyGenerateRequest creates the request with the default values.
+func NewApplicationKeyGenerateRequest(keyType ApplicationKeyType) *ApplicationKeyGenerateRequest {
+	return &ApplicationKeyGenerateRequest{
+		KeyType:             keyType,
+		ValidityTime:        "3600",
+		AccessAllowDomains:  []string{"ALL"},
+		Scopes:              []string{"am_application_scope", "default"},
+		SupportedGrantTypes: []string{"client_credentials", "password", "refresh_token"},
+	}
+}
+
+// GenerateApplicationKeys generates the consumer key and secret of the application.
+func (c *Client) GenerateApplicationKeys(appID string, req *ApplicationKeyGenerateRequest) (*ApplicationKey, error) {
+	path := "applications/" + appID + "/generate-keys"
+	if strings.HasPrefix(c.config.APIVersion, "v0.") {
+		path = "applications/generate-keys?applicationId=" + url.QueryEscape(appID)
+	}
+	var v ApplicationKey
+	if err := c.post(c.storeURL(path), "apim:subscribe", newJSONRequestBody(req), &v); err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
+
+// ApplicationKeys returns the keys of the type.
+func (c *Client) ApplicationKeys(appID string, keyType ApplicationKeyType) (*ApplicationKey, error) {
+	var v ApplicationKey
+	if err := c.get(c.storeURL("applications/"+appID+"/keys/"+string(keyType)), "apim:subscribe", &v); err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
+
+// UpdateApplicationKeys updates the grant types and the callback URL of the keys.
+func (c *Client) UpdateApplicationKeys(appID string, keyType ApplicationKeyType, grantTypes []string, callbackURL string) (*ApplicationKey, error) {
+	key := &ApplicationKey{
+		SupportedGrantTypes: grantTypes,
+		CallbackURL:         callbackURL,
+		KeyType:             keyType,
+	}
+	var v ApplicationKey
+	if err := c.put(c.storeURL("applications/"+appID+"/keys/"+string(keyType)), "apim:subscribe", newJSONRequestBody(key), &v); err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
+
+// RegenerateConsumerSecret regenerates the consumer secret of the keys.
+// The returned key has the consumer key and the new secret only.
+func (c *Client) RegenerateConsumerSecret(appID string, keyType ApplicationKeyType) (*ApplicationKey, error) {
+	if err := c.checkStoreV1("regenerating the consumer secret"); err != nil {
+		return nil, err
+	}
+	var v ApplicationKey
+	if err := c.post(c.storeURL("applications/"+appID+"/keys/"+string(keyType)+"/regenerate-secret"), "apim:subscribe", nil, &v); err != nil {
+		return nil, err
+	}
+	v.KeyType = keyType
+	return &v, nil
+}
+
+// MapApplicationKeys maps the existing OAuth client to the application.
+func (c *Client) MapApplicationKeys(appID string, req *ApplicationKeyMappingRequest) (*ApplicationKey, error) {
+	if err := c.checkStoreV1("mapping the keys"); err != nil {
+		return nil, err
+	}
+	var v ApplicationKey
+	if err := c.post(c.storeURL("applications/"+appID+"/map-keys"), "apim:subscribe", newJSONRequestBody(req), &v); err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
+
+// ApplicationAccessToken obtains the access token with the client credentials grant of the keys.
+// The validity is in seconds, and the server default is used if it is zero.
+func (c *Client) ApplicationAccessToken(key *ApplicationKey, scopes []string, validity int) (*AccessToken, error) {
+	if key.ConsumerKey == "" || key.ConsumerSecret == "" {
+		return nil, fmt.Errorf("consumer key and secret are required to obtain the access token")
+	}
+	body := newFormRequestBody()
+	body.Add("grant_type", "client_credentials")
+	if len(scopes) > 0 {
+		body.Add("scope", strings.Join(scopes, " "))
+	}
+	if validity > 0 {
+		body.Add("validity_period", strconv.Itoa(validity))
+	}
+	req, _ := http.NewRequest("POST", c.endpointToken("token"), nil)
+	req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+	req.SetBasicAuth(key.ConsumerKey, key.ConsumerSecret)
+
+	var v AccessToken
+	if err := c.do(req, body, &v); err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
+
+func (c *Client) checkStoreV1(feature string) error {
+	if strings.HasPrefix(c.config.APIVersion, "v0.") {
+		return fmt.Errorf("%s is not supported by the store API %s; use v1 or later", feature, c.config.APIVersion)
+	}
+	return nil
+}
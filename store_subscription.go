@@ -0,0 +1,167 @@
+package wso2am
+
+import (
+	"fmt"
+	"strings"
+)
+
+// storeSubscription is the subscription of the store API.
+// The store API v1 renamed apiIdentifier and tier to apiId and throttlingPolicy.
+type storeSubscription struct {
+	ID               string `json:"subscriptionId,omitempty"`
+	ApplicationID    string `json:"applicationId"`
+	APIIdentifier    string `json:"apiIdentifier,omitempty"`
+	APIID            string `json:"apiId,omitempty"`
+	Tier             string `json:"tier,omitempty"`
+	ThrottlingPolicy string `json:"throttlingPolicy,omitempty"`
+	Status           string `json:"status,omitempty"`
+}
+
+func (c *Client) newStoreSubscription(appID, apiID, tier string) *storeSubscription {
+	if strings.HasPrefix(c.config.APIVersion, "v0.") {
+		return &storeSubscription{ApplicationID: appID, APIIdentifier: apiID, Tier: tier}
+	}
+	return &storeSubscription{ApplicationID: appID, APIID: apiID, ThrottlingPolicy: tier}
+}
+
+func (s *storeSubscription) subscription() *Subscription {
+	v := &Subscription{
+		ID:            s.ID,
+		Tier:          s.Tier,
+		APIIdentifier: s.APIIdentifier,
+		ApplicationID: s.ApplicationID,
+		Status:        s.Status,
+	}
+	if v.Tier == "" {
+		v.Tier = s.ThrottlingPolicy
+	}
+	if v.APIIdentifier == "" {
+		v.APIIdentifier = s.APIID
+	}
+	return v
+}
+
+// Subscribe subscribes the application to the API with the tier.
+func (c *Client) Subscribe(appID, apiID, tier string) (*Subscription, error) {
+	var v storeSubscription
+	if err := c.post(c.storeURL("subscriptions"), "apim:subscribe", newJSONRequestBody(c.newStoreSubscription(appID, apiID, tier)), &v); err != nil {
+		return nil, err
+	}
+	return v.subscription(), nil
+}
+
+// SubscribeMultiple creates the subscriptions at once.
+// The subscriptions need ApplicationID, APIIdentifier and Tier.
+func (c *Client) SubscribeMultiple(subscriptions []Subscription) ([]Subscription, error) {
+	req := []*storeSubscription{}
+	for _, s := range subscriptions {
+		req = append(req, c.newStoreSubscription(s.ApplicationID, s.APIIdentifier, s.Tier))
+	}
+	var v []storeSubscription
+	if err := c.post(c.storeURL("subscriptions/multiple"), "apim:subscribe", newJSONRequestBody(req), &v); err != nil {
+		return nil, err
+	}
+	result := []Subscription{}
+	for _, s := range v {
+		result = append(result, *s.subscription())
+	}
+	return result, nil
+}
+
+// SubscribeAPIs subscribes the application to the APIs with the tier.
+func (c *Client) SubscribeAPIs(appID string, apiIDs []string, tier string) ([]Subscription, error) {
+	subscriptions := []Subscription{}
+	for _, apiID := range apiIDs {
+		subscriptions = append(subscriptions, Subscription{ApplicationID: appID, APIIdentifier: apiID, Tier: tier})
+	}
+	return c.SubscribeMultiple(subscriptions)
+}
+
+// SubscribeApplications subscribes the applications to the API with the tier.
+func (c *Client) SubscribeApplications(apiID string, appIDs []string, tier string) ([]Subscription, error) {
+	subscriptions := []Subscription{}
+	for _, appID := range appIDs {
+		subscriptions = append(subscriptions, Subscription{ApplicationID: appID, APIIdentifier: apiID, Tier: tier})
+	}
+	return c.SubscribeMultiple(subscriptions)
+}
+
+// Unsubscribe deletes the subscription.
+func (c *Client) Unsubscribe(subscriptionID string) error {
+	return c.delete(c.storeURL("subscriptions/"+subscriptionID), "apim:subscribe", nil)
+}
+
+// StoreSubscriptionsRaw searches the subscriptions of the application, or to the API.
+// Either appID or apiID is required.
+func (c *Client) StoreSubscriptionsRaw(appID, apiID string, entryc chan<- interface{}, errc chan<- error, done <-chan struct{}) {
+	c.search(entryc, errc, done, func(q *PageQuery) (*PageResponse, error) {
+		params := pageQueryParams(q)
+		if appID != "" {
+			params.Add("applicationId", appID)
+		}
+		if apiID != "" {
+			params.Add("apiId", apiID)
+		}
+		var v PageResponse
+		if err := c.get(c.storeURL("subscriptions?"+params.Encode()), "apim:subscribe", &v); err != nil {
+			return nil, err
+		}
+		for i, s := range v.List {
+			var sub storeSubscription
+			if err := convert(s, &sub); err != nil {
+				return nil, err
+			}
+			v.List[i] = sub.subscription()
+		}
+		return &v, nil
+	})
+}
+
+// FindSubscription finds the subscription of the application to the API.
+// It returns nil if the application does not subscribe the API.
+func (c *Client) FindSubscription(appID, apiID string) (*Subscription, error) {
+	result, err := c.SearchResultToSlice(func(entryc chan<- interface{}, errc chan<- error, done <-chan struct{}) {
+		c.StoreSubscriptionsRaw("", apiID, entryc, errc, done)
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range result {
+		s := c.ConvertToSubscription(v)
+		// apiIdentifier of the store API v0.x is PROVIDER-NAME-VERSION rather than the ID
+		if s.ApplicationID == appID {
+			return s, nil
+		}
+	}
+	return nil, nil
+}
+
+// ChangeSubscriptionTier changes the tier of the subscription.
+// The store API v0.x cannot update the subscriptions, so the subscription is recreated with the tier.
+// The subscription ID changes in that case.
+func (c *Client) ChangeSubscriptionTier(subscriptionID, tier string) (*Subscription, error) {
+	var current storeSubscription
+	if err := c.get(c.storeURL("subscriptions/"+subscriptionID), "apim:subscribe", &current); err != nil {
+		return nil, err
+	}
+	old := current.subscription()
+	if !strings.HasPrefix(c.config.APIVersion, "v0.") {
+		var v storeSubscription
+		current.ThrottlingPolicy = tier
+		if err := c.put(c.storeURL("subscriptions/"+subscriptionID), "apim:subscribe", newJSONRequestBody(&current), &v); err != nil {
+			return nil, err
+		}
+		return v.subscription(), nil
+	}
+	if err := c.Unsubscribe(subscriptionID); err != nil {
+		return nil, err
+	}
+	s, err := c.Subscribe(old.ApplicationID, old.APIIdentifier, tier)
+	if err != nil {
+		if _, rerr := c.Subscribe(old.ApplicationID, old.APIIdentifier, old.Tier); rerr != nil {
+			return nil, fmt.Errorf("failed to change the tier (%v), and failed to restore the subscription with tier %s: %v", err, old.Tier, rerr)
+		}
+		return nil, err
+	}
+	return s, nil
+}
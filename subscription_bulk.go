@@ -0,0 +1,242 @@
+package wso2am
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"sync"
+	"time"
+)
+
+type (
+	// BulkSubscriptionResult is the result of the operation on a subscription of the bulk operation.
+	BulkSubscriptionResult struct {
+		SubscriptionDetail
+		// PreviousStatus is the status before the operation.
+		PreviousStatus string `json:"previousStatus"`
+		// Changed is true if the status is changed, or would be changed in the dry run.
+		Changed bool `json:"changed"`
+		// Skipped is the reason why the status is not changed, e.g. the subscription waits for the approval.
+		Skipped string `json:"skipped,omitempty"`
+		Error   string `json:"error,omitempty"`
+	}
+	// SubscriptionUndo is the record of the bulk operation to restore the statuses of the subscriptions.
+	SubscriptionUndo struct {
+		Created time.Time               `json:"created"`
+		Entries []SubscriptionUndoEntry `json:"entries"`
+	}
+	// SubscriptionUndoEntry is the status of a subscription before the bulk operation.
+	SubscriptionUndoEntry struct {
+		SubscriptionID string `json:"subscriptionId"`
+		PreviousStatus string `json:"previousStatus"`
+		// Application and API are for the humans reading the file.
+		Application string `json:"application,omitempty"`
+		API         string `json:"api,omitempty"`
+	}
+)
+
+// DefaultBulkParallelism is the default number of the subscriptions processed at once.
+const DefaultBulkParallelism = 4
+
+// BlockSubscriptions blocks the subscriptions in parallel.
+// Only the UNBLOCKED subscriptions, and the PROD_ONLY_BLOCKED ones to be BLOCKED, are changed.
+// The others are skipped, e.g. the BLOCKED ones are not changed to PROD_ONLY_BLOCKED not to loosen the block,
+// and the ON_HOLD and the REJECTED ones are left to the approval workflow.
+// Nothing is changed in the dry run, and the results tell what would be changed.
+func (c *Client) BlockSubscriptions(subscriptions []SubscriptionDetail, state SubscriptionBlockState, parallelism int, dryRun bool) []BulkSubscriptionResult {
+	skip := func(status string) string {
+		switch status {
+		case SubscriptionStatusUnblocked:
+			return ""
+		case string(SubscriptionBlockStateProdOnlyBlocked):
+			if state == SubscriptionBlockStateBlocked {
+				return ""
+			}
+			return "already " + status
+		case string(SubscriptionBlockStateBlocked):
+			return "already " + status
+		}
+		return fmt.Sprintf("%s subscription cannot be blocked", status)
+	}
+	return c.bulkSubscriptions(subscriptions, string(state), skip, parallelism, dryRun, func(id string) (*Subscription, error) {
+		return c.BlockSubscription(id, state)
+	})
+}
+
+// UnblockSubscriptions unblocks the subscriptions in parallel.
+// Only the BLOCKED and the PROD_ONLY_BLOCKED subscriptions are changed, and the others are skipped,
+// e.g. the ON_HOLD and the REJECTED ones are left to the approval workflow.
+// Nothing is changed in the dry run, and the results tell what would be changed.
+func (c *Client) UnblockSubscriptions(subscriptions []SubscriptionDetail, parallelism int, dryRun bool) []BulkSubscriptionResult {
+	skip := func(status string) string {
+		switch status {
+		case string(SubscriptionBlockStateBlocked), string(SubscriptionBlockStateProdOnlyBlocked):
+			return ""
+		case SubscriptionStatusUnblocked:
+			return "already " + status
+		}
+		return fmt.Sprintf("%s subscription cannot be unblocked", status)
+	}
+	return c.bulkSubscriptions(subscriptions, SubscriptionStatusUnblocked, skip, parallelism, dryRun, c.UnblockSubscription)
+}
+
+// bulkSubscriptions changes the subscriptions to the status with f unless skip returns the reason for their current status.
+func (c *Client) bulkSubscriptions(subscriptions []SubscriptionDetail, status string, skip func(string) string, parallelism int, dryRun bool, f func(id string) (*Subscription, error)) []BulkSubscriptionResult {
+	if parallelism <= 0 {
+		parallelism = DefaultBulkParallelism
+	}
+	results := make([]BulkSubscriptionResult, len(subscriptions))
+	indexc := make(chan int)
+	var wg sync.WaitGroup
+	for i := 0; i < parallelism; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexc {
+				s := subscriptions[i]
+				r := BulkSubscriptionResult{
+					SubscriptionDetail: s,
+					PreviousStatus:     s.Status,
+					Skipped:            skip(s.Status),
+				}
+				r.Changed = r.Skipped == ""
+				if r.Changed && !dryRun {
+					if _, err := f(s.ID); err != nil {
+						r.Changed = false
+						r.Error = err.Error()
+					} else {
+						r.Status = status
+					}
+				}
+				results[i] = r
+			}
+		}()
+	}
+	for i := range subscriptions {
+		indexc <- i
+	}
+	close(indexc)
+	wg.Wait()
+	return results
+}
+
+// NewFailedSubscriptionResult creates the result of the subscription which cannot be processed.
+func NewFailedSubscriptionResult(s *Subscription, err error) BulkSubscriptionResult {
+	r := BulkSubscriptionResult{
+		PreviousStatus: s.Status,
+		Error:          err.Error(),
+	}
+	r.Subscription = *s
+	return r
+}
+
+// NewSubscriptionUndo creates the undo record of the changed subscriptions.
+// For the results of the dry run, it records the subscriptions to be changed,
+// so the record can be saved before the changes.
+func NewSubscriptionUndo(results []BulkSubscriptionResult) *SubscriptionUndo {
+	undo := &SubscriptionUndo{
+		Created: time.Now(),
+		Entries: []SubscriptionUndoEntry{},
+	}
+	for _, r := range results {
+		if !r.Changed {
+			continue
+		}
+		undo.Entries = append(undo.Entries, SubscriptionUndoEntry{
+			SubscriptionID: r.ID,
+			PreviousStatus: r.PreviousStatus,
+			Application:    r.ApplicationName,
+			API:            r.APIName + ":" + r.APIVersion,
+		})
+	}
+	return undo
+}
+
+// WriteFile writes the undo record as JSON.
+func (u *SubscriptionUndo) WriteFile(path string) error {
+	data, err := json.MarshalIndent(u, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, data, 0644)
+}
+
+// ReadSubscriptionUndoFile reads the undo record written by WriteFile.
+func ReadSubscriptionUndoFile(path string) (*SubscriptionUndo, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var u SubscriptionUndo
+	if err := json.Unmarshal(data, &u); err != nil {
+		return nil, fmt.Errorf("%s: %v", path, err)
+	}
+	return &u, nil
+}
+
+// RestoreSubscriptions restores the statuses of the subscriptions recorded in the undo record.
+// The subscriptions are looked up again, so the ones already restored are not changed.
+func (c *Client) RestoreSubscriptions(undo *SubscriptionUndo, parallelism int, dryRun bool) []BulkSubscriptionResult {
+	if parallelism <= 0 {
+		parallelism = DefaultBulkParallelism
+	}
+	results := make([]BulkSubscriptionResult, len(undo.Entries))
+	resolver := NewSubscriptionResolver(c)
+	indexc := make(chan int)
+	var wg sync.WaitGroup
+	for i := 0; i < parallelism; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexc {
+				results[i] = c.restoreSubscription(resolver, undo.Entries[i], dryRun)
+			}
+		}()
+	}
+	for i := range undo.Entries {
+		indexc <- i
+	}
+	close(indexc)
+	wg.Wait()
+	return results
+}
+
+func (c *Client) restoreSubscription(resolver *SubscriptionResolver, e SubscriptionUndoEntry, dryRun bool) BulkSubscriptionResult {
+	r := BulkSubscriptionResult{}
+	r.ID = e.SubscriptionID
+	s, err := c.Subscription(e.SubscriptionID)
+	if err == nil {
+		var detail *SubscriptionDetail
+		detail, err = resolver.Resolve(s)
+		if detail != nil {
+			r.SubscriptionDetail = *detail
+		}
+	}
+	if err != nil {
+		r.Error = err.Error()
+		return r
+	}
+	r.PreviousStatus = s.Status
+	switch e.PreviousStatus {
+	case SubscriptionStatusUnblocked, string(SubscriptionBlockStateBlocked), string(SubscriptionBlockStateProdOnlyBlocked):
+	default:
+		r.Skipped = fmt.Sprintf("%s status cannot be restored", e.PreviousStatus)
+		return r
+	}
+	r.Changed = s.Status != e.PreviousStatus
+	if !r.Changed || dryRun {
+		return r
+	}
+	if e.PreviousStatus == SubscriptionStatusUnblocked {
+		_, err = c.UnblockSubscription(e.SubscriptionID)
+	} else {
+		_, err = c.BlockSubscription(e.SubscriptionID, SubscriptionBlockState(e.PreviousStatus))
+	}
+	if err != nil {
+		r.Changed = false
+		r.Error = err.Error()
+	} else {
+		r.Status = e.PreviousStatus
+	}
+	return r
+}
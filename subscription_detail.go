@@ -0,0 +1,309 @@
+package wso2am
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+type (
+	// SubscriptionDetail is the subscription with the names of the application and the API.
+	SubscriptionDetail struct {
+		Subscription
+		ApplicationName  string `json:"applicationName"`
+		ApplicationOwner string `json:"applicationOwner"`
+		APIID            string `json:"apiId"`
+		APIName          string `json:"apiName"`
+		APIVersion       string `json:"apiVersion"`
+		APIProvider      string `json:"apiProvider"`
+	}
+	// SubscriptionResolver resolves the applications and the APIs of the subscriptions.
+	// The lookups are cached, so a resolver should be used for a single listing.
+	// It is safe for concurrent use.
+	SubscriptionResolver struct {
+		client *Client
+		mutex  sync.Mutex
+		// cache is the results of the lookups including the failed ones, which are not retried.
+		cache map[string]lookupResult
+		// index is the publisher APIs by the identifiers, loaded once for the PROVIDER-NAME-VERSION identifiers.
+		index     map[string]*API
+		indexErr  error
+		indexOnce sync.Once
+	}
+	lookupResult struct {
+		value interface{}
+		err   error
+	}
+	// SubscriptionFilter selects the subscriptions.  The empty fields match any subscription.
+	SubscriptionFilter struct {
+		Status string
+		// Application is the ID or the name of the application.
+		Application string
+		Tier        string
+		APIName     string
+	}
+	// SubscriptionSummary is the number of the subscriptions to the API by the status.
+	SubscriptionSummary struct {
+		APIID      string         `json:"apiId"`
+		APIName    string         `json:"apiName"`
+		APIVersion string         `json:"apiVersion"`
+		Total      int            `json:"total"`
+		Statuses   map[string]int `json:"statuses"`
+	}
+)
+
+// SubscriberApplication returns the application of the subscriber.
+// Unlike Application, it can return the applications of the other users.
+// It is available in the publisher API v0.x only.
+func (c *Client) SubscriberApplication(id string) (*Application, error) {
+	if !strings.HasPrefix(c.config.APIVersion, "v0.") {
+		return nil, fmt.Errorf("looking up the applications is not supported by the publisher API %s", c.config.APIVersion)
+	}
+	var v Application
+	if err := c.get(c.publisherURL("applications/"+id), "apim:subscription_view", &v); err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
+
+func NewSubscriptionResolver(client *Client) *SubscriptionResolver {
+	return &SubscriptionResolver{
+		client: client,
+		cache:  map[string]lookupResult{},
+	}
+}
+
+// Resolve looks up the application and the API of the subscription.
+func (r *SubscriptionResolver) Resolve(s *Subscription) (*SubscriptionDetail, error) {
+	detail, errs := r.ResolvePartially(s)
+	if len(errs) > 0 {
+		return nil, errs[0]
+	}
+	return detail, nil
+}
+
+// ResolvePartially looks up the application and the API of the subscription like Resolve,
+// but the names are left empty for the failed lookups, which are returned as the errors.
+func (r *SubscriptionResolver) ResolvePartially(s *Subscription) (*SubscriptionDetail, []error) {
+	detail := &SubscriptionDetail{Subscription: *s}
+	errs := []error{}
+	if app, err := r.application(s.ApplicationID); err != nil {
+		errs = append(errs, fmt.Errorf("application %s: %v", s.ApplicationID, err))
+	} else {
+		detail.ApplicationName = app.Name
+		detail.ApplicationOwner = app.Subscriber
+	}
+	if api, err := r.api(s.APIIdentifier); err != nil {
+		errs = append(errs, fmt.Errorf("API %s: %v", s.APIIdentifier, err))
+	} else {
+		detail.APIID = api.ID
+		detail.APIName = api.Name
+		detail.APIVersion = api.Version
+		detail.APIProvider = api.Provider
+	}
+	return detail, errs
+}
+
+func (r *SubscriptionResolver) application(id string) (*Application, error) {
+	v, err := r.lookup("application:"+id, func() (interface{}, error) {
+		return r.client.SubscriberApplication(id)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return v.(*Application), nil
+}
+
+// api looks up the API by the identifier of the subscription.
+// The identifier is the API ID, or PROVIDER-NAME-VERSION in some versions of the API Manager.
+func (r *SubscriptionResolver) api(identifier string) (*API, error) {
+	v, err := r.lookup("api:"+identifier, func() (interface{}, error) {
+		if UUIDPattern.MatchString(identifier) {
+			detail, err := r.client.API(identifier)
+			if err != nil {
+				return nil, err
+			}
+			return &detail.API, nil
+		}
+		// the provider (e.g. admin-AT-tenant.com) and the version (e.g. 1.0.0-beta) can contain "-",
+		// so the identifier is compared with the identifiers of the publisher APIs instead of splitting it.
+		r.indexOnce.Do(r.loadIndex)
+		if r.indexErr != nil {
+			return nil, r.indexErr
+		}
+		api, ok := r.index[identifier]
+		if !ok {
+			return nil, fmt.Errorf("API not found: %s", identifier)
+		}
+		return api, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return v.(*API), nil
+}
+
+// lookup returns the cached result of f for the key, or calls f and caches the result including the error.
+func (r *SubscriptionResolver) lookup(key string, f func() (interface{}, error)) (interface{}, error) {
+	r.mutex.Lock()
+	v, ok := r.cache[key]
+	r.mutex.Unlock()
+	if ok {
+		return v.value, v.err
+	}
+	value, err := f()
+	r.mutex.Lock()
+	r.cache[key] = lookupResult{value, err}
+	r.mutex.Unlock()
+	return value, err
+}
+
+func (r *SubscriptionResolver) loadIndex() {
+	result, err := r.client.SearchResultToSlice(func(entryc chan<- interface{}, errc chan<- error, done <-chan struct{}) {
+		r.client.SearchAPIsRaw("", entryc, errc, done)
+	})
+	if err != nil {
+		r.indexErr = err
+		return
+	}
+	var apis []API
+	if err := convert(result, &apis); err != nil {
+		r.indexErr = err
+		return
+	}
+	r.index = map[string]*API{}
+	for i := range apis {
+		api := &apis[i]
+		r.index[api.Provider+"-"+api.Name+"-"+api.Version] = api
+		r.index[strings.Replace(api.Provider, "@", "-AT-", -1)+"-"+api.Name+"-"+api.Version] = api
+	}
+}
+
+// SubscriptionDetails returns the subscriptions to the API which match the filter.
+// All the subscriptions are searched if apiID is empty, and the filter can be nil.
+// The subscriptions whose application or API cannot be looked up have the empty names,
+// and the failed lookups are returned as the warnings.
+func (c *Client) SubscriptionDetails(apiID string, filter *SubscriptionFilter) ([]SubscriptionDetail, []error, error) {
+	subscriptions, err := c.apiSubscriptions(apiID)
+	if err != nil {
+		return nil, nil, err
+	}
+	resolver := NewSubscriptionResolver(c)
+	result := []SubscriptionDetail{}
+	warnings := []error{}
+	warned := map[string]bool{}
+	for i := range subscriptions {
+		if filter != nil && !filter.matchSubscription(&subscriptions[i]) {
+			continue
+		}
+		s, errs := resolver.ResolvePartially(&subscriptions[i])
+		for _, err := range errs {
+			if !warned[err.Error()] {
+				warned[err.Error()] = true
+				warnings = append(warnings, err)
+			}
+		}
+		if filter == nil || filter.Match(s) {
+			result = append(result, *s)
+		}
+	}
+	return result, warnings, nil
+}
+
+// FindSubscriptionDetails returns the subscriptions to the API which match the filter like SubscriptionDetails,
+// and the failed results of the subscriptions whose application or API cannot be looked up.
+// The subscriptions are filtered by the status, the tier and the application ID before the lookups.
+func (c *Client) FindSubscriptionDetails(apiID string, filter *SubscriptionFilter) ([]SubscriptionDetail, []BulkSubscriptionResult, error) {
+	subscriptions, err := c.apiSubscriptions(apiID)
+	if err != nil {
+		return nil, nil, err
+	}
+	resolver := NewSubscriptionResolver(c)
+	result := []SubscriptionDetail{}
+	failures := []BulkSubscriptionResult{}
+	for i := range subscriptions {
+		if filter != nil && !filter.matchSubscription(&subscriptions[i]) {
+			continue
+		}
+		s, err := resolver.Resolve(&subscriptions[i])
+		if err != nil {
+			failures = append(failures, NewFailedSubscriptionResult(&subscriptions[i], err))
+			continue
+		}
+		if filter == nil || filter.Match(s) {
+			result = append(result, *s)
+		}
+	}
+	return result, failures, nil
+}
+
+// matchSubscription returns false if the subscription does not match the conditions
+// which can be checked without looking up the application and the API.
+func (f *SubscriptionFilter) matchSubscription(s *Subscription) bool {
+	if f.Status != "" && !strings.EqualFold(f.Status, s.Status) {
+		return false
+	}
+	if f.Tier != "" && !strings.EqualFold(f.Tier, s.Tier) {
+		return false
+	}
+	if UUIDPattern.MatchString(f.Application) && f.Application != s.ApplicationID {
+		return false
+	}
+	return true
+}
+
+// Match returns true if the subscription matches all the conditions.
+// The status, the tier and the API name are compared case-insensitively.
+func (f *SubscriptionFilter) Match(s *SubscriptionDetail) bool {
+	if f.Status != "" && !strings.EqualFold(f.Status, s.Status) {
+		return false
+	}
+	if f.Application != "" && f.Application != s.ApplicationID && f.Application != s.ApplicationName {
+		return false
+	}
+	if f.Tier != "" && !strings.EqualFold(f.Tier, s.Tier) {
+		return false
+	}
+	if f.APIName != "" && !strings.EqualFold(f.APIName, s.APIName) {
+		return false
+	}
+	return true
+}
+
+// SummarizeSubscriptions counts the subscriptions by the API.
+// The summaries are sorted by the API name and version.
+func SummarizeSubscriptions(subscriptions []SubscriptionDetail) []SubscriptionSummary {
+	summaries := map[string]*SubscriptionSummary{}
+	for _, s := range subscriptions {
+		key := s.APIProvider + "/" + s.APIName + "/" + s.APIVersion
+		apiID := s.APIID
+		if s.APIName == "" {
+			// the API is not looked up
+			key, apiID = s.APIIdentifier, s.APIIdentifier
+		}
+		summary, ok := summaries[key]
+		if !ok {
+			summary = &SubscriptionSummary{
+				APIID:      apiID,
+				APIName:    s.APIName,
+				APIVersion: s.APIVersion,
+				Statuses:   map[string]int{},
+			}
+			summaries[key] = summary
+		}
+		summary.Total++
+		summary.Statuses[s.Status]++
+	}
+	result := []SubscriptionSummary{}
+	for _, s := range summaries {
+		result = append(result, *s)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].APIName != result[j].APIName {
+			return result[i].APIName < result[j].APIName
+		}
+		return result[i].APIVersion < result[j].APIVersion
+	})
+	return result
+}
@@ -0,0 +1,162 @@
+package wso2am
+
+import "fmt"
+
+type (
+	// SubscriptionMigrationOptions are the options of MigrateSubscriptions.
+	SubscriptionMigrationOptions struct {
+		// TierMapping maps the tiers of the old subscriptions to the tiers of the new ones.
+		// The unmapped tiers are kept as is.
+		TierMapping map[string]string
+		// BlockOld blocks the old subscriptions after migrating them.
+		BlockOld bool
+		// DryRun reports what would be done without changing anything.
+		DryRun bool
+	}
+	// SubscriptionMigrationReport is the result of MigrateSubscriptions.
+	SubscriptionMigrationReport struct {
+		From    string                             `json:"from"`
+		To      string                             `json:"to"`
+		DryRun  bool                               `json:"dryRun"`
+		Entries []SubscriptionMigrationReportEntry `json:"entries"`
+	}
+	SubscriptionMigrationReportEntry struct {
+		ApplicationID     string `json:"applicationId"`
+		OldSubscriptionID string `json:"oldSubscriptionId"`
+		NewSubscriptionID string `json:"newSubscriptionId,omitempty"`
+		OldTier           string `json:"oldTier"`
+		NewTier           string `json:"newTier"`
+		// OldStatus is the status of the old subscription, which is also set to the created subscription.
+		OldStatus string                      `json:"oldStatus"`
+		Result    SubscriptionMigrationResult `json:"result"`
+		// Blocked is true if the old subscription is blocked by this migration.
+		Blocked bool   `json:"blocked"`
+		Error   string `json:"error,omitempty"`
+	}
+	SubscriptionMigrationResult string
+)
+
+const (
+	// SubscriptionMigrationCreated means the subscription is created on the new API.
+	SubscriptionMigrationCreated SubscriptionMigrationResult = "CREATED"
+	// SubscriptionMigrationExists means the application already subscribes the new API, e.g. by the previous run.
+	SubscriptionMigrationExists SubscriptionMigrationResult = "EXISTS"
+	// SubscriptionMigrationSkipped means the old subscription is neither active nor PROD_ONLY_BLOCKED, and is not migrated.
+	SubscriptionMigrationSkipped SubscriptionMigrationResult = "SKIPPED"
+	SubscriptionMigrationFailed  SubscriptionMigrationResult = "FAILED"
+)
+
+// Failed returns the number of the failed entries.
+func (r *SubscriptionMigrationReport) Failed() int {
+	n := 0
+	for _, e := range r.Entries {
+		if e.Result == SubscriptionMigrationFailed {
+			n++
+		}
+	}
+	return n
+}
+
+// MigrateSubscriptions recreates the active and the PROD_ONLY_BLOCKED subscriptions of the old API on the new API
+// with the same applications, the (mapped) tiers and the statuses.
+// The applications which already subscribe the new API are not subscribed again, so the migration can be re-run.
+// The store API subscribes as the user of the client, so the applications must be accessible to the user.
+// The failures of the entries are recorded in the report, and the error is returned only if the migration cannot start.
+func (c *Client) MigrateSubscriptions(fromAPIID, toAPIID string, opts *SubscriptionMigrationOptions) (*SubscriptionMigrationReport, error) {
+	if opts == nil {
+		opts = &SubscriptionMigrationOptions{}
+	}
+	if fromAPIID == toAPIID {
+		return nil, fmt.Errorf("the old and the new APIs are the same: %s", fromAPIID)
+	}
+	to, err := c.API(toAPIID)
+	if err != nil {
+		return nil, err
+	}
+	oldSubscriptions, err := c.apiSubscriptions(fromAPIID)
+	if err != nil {
+		return nil, err
+	}
+	newSubscriptions, err := c.apiSubscriptions(toAPIID)
+	if err != nil {
+		return nil, err
+	}
+	subscribed := map[string]Subscription{}
+	for _, s := range newSubscriptions {
+		subscribed[s.ApplicationID] = s
+	}
+
+	report := &SubscriptionMigrationReport{
+		From:    fromAPIID,
+		To:      toAPIID,
+		DryRun:  opts.DryRun,
+		Entries: []SubscriptionMigrationReportEntry{},
+	}
+	for _, old := range oldSubscriptions {
+		migratable := old.Status == SubscriptionStatusUnblocked || old.Status == string(SubscriptionBlockStateProdOnlyBlocked)
+		entry := SubscriptionMigrationReportEntry{
+			ApplicationID:     old.ApplicationID,
+			OldSubscriptionID: old.ID,
+			OldTier:           old.Tier,
+			NewTier:           old.Tier,
+			OldStatus:         old.Status,
+		}
+		if t, ok := opts.TierMapping[old.Tier]; ok {
+			entry.NewTier = t
+		}
+		if s, ok := subscribed[old.ApplicationID]; ok {
+			entry.Result = SubscriptionMigrationExists
+			entry.NewSubscriptionID = s.ID
+			entry.NewTier = s.Tier
+		} else if !migratable {
+			entry.Result = SubscriptionMigrationSkipped
+			entry.Error = "old subscription is " + old.Status
+		} else if err := checkAvailable("tier of API "+to.Name, entry.NewTier, to.Tiers); err != nil {
+			entry.Result = SubscriptionMigrationFailed
+			entry.Error = err.Error()
+		} else if opts.DryRun {
+			entry.Result = SubscriptionMigrationCreated
+		} else if s, err := c.Subscribe(old.ApplicationID, toAPIID, entry.NewTier); err != nil {
+			entry.Result = SubscriptionMigrationFailed
+			entry.Error = err.Error()
+		} else {
+			entry.Result = SubscriptionMigrationCreated
+			entry.NewSubscriptionID = s.ID
+			// keep the sandbox access only like the old subscription
+			if old.Status == string(SubscriptionBlockStateProdOnlyBlocked) {
+				if _, err := c.BlockSubscription(s.ID, SubscriptionBlockStateProdOnlyBlocked); err != nil {
+					entry.Result = SubscriptionMigrationFailed
+					entry.Error = fmt.Sprintf("failed to block the production access of the new subscription: %v", err)
+				}
+			}
+		}
+
+		migrated := entry.Result == SubscriptionMigrationCreated || entry.Result == SubscriptionMigrationExists
+		if opts.BlockOld && migrated && migratable {
+			if !opts.DryRun {
+				if _, err := c.BlockSubscription(old.ID, SubscriptionBlockStateBlocked); err != nil {
+					entry.Result = SubscriptionMigrationFailed
+					entry.Error = fmt.Sprintf("failed to block the old subscription: %v", err)
+				}
+			}
+			entry.Blocked = entry.Result != SubscriptionMigrationFailed
+		}
+		report.Entries = append(report.Entries, entry)
+	}
+	return report, nil
+}
+
+// apiSubscriptions returns all the subscriptions to the API.
+func (c *Client) apiSubscriptions(apiID string) ([]Subscription, error) {
+	result, err := c.SearchResultToSlice(func(entryc chan<- interface{}, errc chan<- error, done <-chan struct{}) {
+		c.SubscriptionsByAPIRaw(apiID, entryc, errc, done)
+	})
+	if err != nil {
+		return nil, err
+	}
+	subscriptions := []Subscription{}
+	for _, v := range result {
+		subscriptions = append(subscriptions, *c.ConvertToSubscription(v))
+	}
+	return subscriptions, nil
+}
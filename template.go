@@ -0,0 +1,162 @@
+package wso2am
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+	"text/template"
+
+	yaml "gopkg.in/yaml.v2"
+)
+
+// Template renders the files and values with Go text/template.
+//
+// The values are available as {{ .Values.KEY }} and the environment variables are
+// available as {{ .Env.NAME }} or {{ env "NAME" }}.
+// In strict mode, rendering fails if an undefined value or environment variable is referred.
+type Template struct {
+	Values map[string]interface{}
+	Env    map[string]string
+	Strict bool
+}
+
+const (
+	// templateNoValue is printed by text/template for the missing keys.
+	templateNoValue = "<no value>"
+	// templateNoValueEscape replaces the literal templateNoValue in the text while rendering,
+	// so that only the output of the missing keys is removed.
+	templateNoValueEscape = "\x00no value\x00"
+)
+
+// NewTemplate creates a Template with the environment variables of the current process.
+func NewTemplate(strict bool) *Template {
+	env := map[string]string{}
+	for _, e := range os.Environ() {
+		if i := strings.Index(e, "="); i > 0 {
+			env[e[:i]] = e[i+1:]
+		}
+	}
+	return &Template{
+		Values: map[string]interface{}{},
+		Env:    env,
+		Strict: strict,
+	}
+}
+
+// LoadValuesFile merges the values in the YAML file.
+func (t *Template) LoadValuesFile(path string) error {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	var v map[string]interface{}
+	if err := yaml.Unmarshal(data, &v); err != nil {
+		return fmt.Errorf("failed to parse the values file %s: %v", path, err)
+	}
+	mergeValues(t.Values, asMap(normalizeYAML(v)))
+	return nil
+}
+
+// Set sets the value.  The dotted key like "a.b" sets the nested value.
+func (t *Template) Set(key string, value interface{}) {
+	keys := strings.Split(key, ".")
+	m := t.Values
+	for _, k := range keys[:len(keys)-1] {
+		child, ok := m[k].(map[string]interface{})
+		if !ok {
+			child = map[string]interface{}{}
+			m[k] = child
+		}
+		m = child
+	}
+	m[keys[len(keys)-1]] = value
+}
+
+// SetString sets the value by the "KEY=VALUE" expression.
+func (t *Template) SetString(expr string) error {
+	i := strings.Index(expr, "=")
+	if i <= 0 {
+		return fmt.Errorf("invalid value %q (expected KEY=VALUE)", expr)
+	}
+	t.Set(expr[:i], expr[i+1:])
+	return nil
+}
+
+// Render renders the text.  The name is used in the error messages.
+func (t *Template) Render(name, text string) (string, error) {
+	missingKey := "missingkey=error"
+	if !t.Strict {
+		missingKey = "missingkey=default"
+		text = strings.Replace(text, templateNoValue, templateNoValueEscape, -1)
+	}
+	tmpl, err := template.New(name).Option(missingKey).Funcs(template.FuncMap{
+		"env": func(name string) (string, error) {
+			v, ok := t.Env[name]
+			if !ok && t.Strict {
+				return "", fmt.Errorf("environment variable %s is not defined", name)
+			}
+			return v, nil
+		},
+		"default": func(def interface{}, v interface{}) interface{} {
+			if v == nil || v == "" {
+				return def
+			}
+			return v
+		},
+	}).Parse(text)
+	if err != nil {
+		return "", err
+	}
+	buf := new(bytes.Buffer)
+	if err := tmpl.Execute(buf, map[string]interface{}{
+		"Values": t.Values,
+		"Env":    t.Env,
+	}); err != nil {
+		return "", err
+	}
+	s := buf.String()
+	if !t.Strict {
+		s = strings.Replace(s, templateNoValue, "", -1)
+		s = strings.Replace(s, templateNoValueEscape, templateNoValue, -1)
+	}
+	return s, nil
+}
+
+func (t *Template) renderFile(path string) ([]byte, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if t == nil {
+		return data, nil
+	}
+	s, err := t.Render(path, string(data))
+	if err != nil {
+		return nil, err
+	}
+	return []byte(s), nil
+}
+
+// NewAPIDefinitionFromFile renders the API definition file and loads it.
+func (t *Template) NewAPIDefinitionFromFile(path string) (APIDefinition, error) {
+	return newAPIDefinitionFromFile(path, t)
+}
+
+// BundleAPIDefinition renders the API definition file and the referred files, and bundles them.
+func (t *Template) BundleAPIDefinition(path string) (APIDefinition, error) {
+	return bundleAPIDefinition(path, t)
+}
+
+func mergeValues(dst, src map[string]interface{}) {
+	for k, v := range src {
+		if m, ok := v.(map[string]interface{}); ok {
+			if d, ok := dst[k].(map[string]interface{}); ok {
+				mergeValues(d, m)
+				continue
+			}
+		}
+		dst[k] = v
+	}
+}
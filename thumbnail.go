@@ -0,0 +1,298 @@
+package wso2am
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"image"
+	"image/color"
+	_ "image/gif" // registers the GIF decoder
+	"image/jpeg"
+	"image/png"
+	"io"
+	"io/ioutil"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"strconv"
+	"strings"
+)
+
+// ThumbnailImage is an image for the thumbnail of the APIs and the API Products.
+type ThumbnailImage struct {
+	Data      []byte
+	MediaType string
+	// Width and Height are the size of the image in pixels.
+	// They are zero for the SVG images which have no explicit size.
+	Width  int
+	Height int
+}
+
+const (
+	// ThumbnailMaxBytes is the maximum size of the thumbnail file.
+	ThumbnailMaxBytes = 1 << 20
+	// ThumbnailMaxDimension is the maximum width and height of the thumbnail.
+	ThumbnailMaxDimension = 2048
+	// ThumbnailPreferredSize is the width and height which the store displays the thumbnails with.
+	ThumbnailPreferredSize = 150
+
+	mediaTypePNG  = "image/png"
+	mediaTypeJPEG = "image/jpeg"
+	mediaTypeGIF  = "image/gif"
+	mediaTypeSVG  = "image/svg+xml"
+)
+
+var thumbnailExtensions = map[string]string{
+	mediaTypePNG:  ".png",
+	mediaTypeJPEG: ".jpg",
+	mediaTypeGIF:  ".gif",
+	mediaTypeSVG:  ".svg",
+}
+
+// ThumbnailExtension returns the file extension for the media type of the thumbnail.
+// It returns an empty string for the unknown media types.
+func ThumbnailExtension(mediaType string) string {
+	return thumbnailExtensions[mediaType]
+}
+
+// NewThumbnailImage detects the media type and the size of the image.
+// PNG, JPEG, GIF and SVG are supported.
+func NewThumbnailImage(data []byte) (*ThumbnailImage, error) {
+	mediaType := detectThumbnailMediaType(data)
+	img := &ThumbnailImage{
+		Data:      data,
+		MediaType: mediaType,
+	}
+	switch mediaType {
+	case mediaTypePNG, mediaTypeJPEG, mediaTypeGIF:
+		config, _, err := image.DecodeConfig(bytes.NewReader(data))
+		if err != nil {
+			return nil, fmt.Errorf("broken %s image: %v", mediaType, err)
+		}
+		img.Width = config.Width
+		img.Height = config.Height
+	case mediaTypeSVG:
+		w, h, err := svgSize(data)
+		if err != nil {
+			return nil, fmt.Errorf("broken SVG image: %v", err)
+		}
+		img.Width = w
+		img.Height = h
+	default:
+		return nil, fmt.Errorf("unsupported thumbnail type: %s (supported: PNG, JPEG, GIF and SVG)", mediaType)
+	}
+	return img, nil
+}
+
+// ReadThumbnailFile reads the image file for the thumbnail.
+func ReadThumbnailFile(path string) (*ThumbnailImage, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	img, err := NewThumbnailImage(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", path, err)
+	}
+	return img, nil
+}
+
+func detectThumbnailMediaType(data []byte) string {
+	mediaType, _, _ := mime.ParseMediaType(http.DetectContentType(data))
+	if strings.HasPrefix(mediaType, "text/") && isSVG(data) {
+		return mediaTypeSVG
+	}
+	return mediaType
+}
+
+func isSVG(data []byte) bool {
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	for {
+		tok, err := decoder.Token()
+		if err != nil {
+			return false
+		}
+		if start, ok := tok.(xml.StartElement); ok {
+			return start.Name.Local == "svg"
+		}
+	}
+}
+
+// svgSize returns the width and height attributes of the SVG image in pixels.
+// The viewBox is used if they are not specified, and zeros are returned if neither is specified.
+func svgSize(data []byte) (int, int, error) {
+	var svg struct {
+		Width   string `xml:"width,attr"`
+		Height  string `xml:"height,attr"`
+		ViewBox string `xml:"viewBox,attr"`
+	}
+	if err := xml.Unmarshal(data, &svg); err != nil {
+		return 0, 0, err
+	}
+	w, wok := svgLength(svg.Width)
+	h, hok := svgLength(svg.Height)
+	if wok && hok {
+		return w, h, nil
+	}
+	if fields := strings.Fields(strings.Replace(svg.ViewBox, ",", " ", -1)); len(fields) == 4 {
+		vw, err1 := strconv.ParseFloat(fields[2], 64)
+		vh, err2 := strconv.ParseFloat(fields[3], 64)
+		if err1 == nil && err2 == nil {
+			return int(vw), int(vh), nil
+		}
+	}
+	return 0, 0, nil
+}
+
+func svgLength(s string) (int, bool) {
+	v, err := strconv.ParseFloat(strings.TrimSuffix(strings.TrimSpace(s), "px"), 64)
+	if err != nil {
+		return 0, false
+	}
+	return int(v), true
+}
+
+// Validate checks the file size and the dimensions of the image.
+func (t *ThumbnailImage) Validate() error {
+	if len(t.Data) > ThumbnailMaxBytes {
+		return fmt.Errorf("thumbnail is too large: %d bytes (max %d bytes)", len(t.Data), ThumbnailMaxBytes)
+	}
+	if t.Width > ThumbnailMaxDimension || t.Height > ThumbnailMaxDimension {
+		return fmt.Errorf("thumbnail is too large: %dx%d (max %dx%d)", t.Width, t.Height, ThumbnailMaxDimension, ThumbnailMaxDimension)
+	}
+	if t.MediaType != mediaTypeSVG && (t.Width == 0 || t.Height == 0) {
+		return fmt.Errorf("thumbnail has no pixels: %dx%d", t.Width, t.Height)
+	}
+	return nil
+}
+
+// Resize shrinks the image to fit in size x size keeping the aspect ratio.
+// The SVG images and the images which already fit are returned as is.
+// The resized image is encoded as PNG, or JPEG if the original is JPEG.
+func (t *ThumbnailImage) Resize(size int) (*ThumbnailImage, error) {
+	if t.MediaType == mediaTypeSVG || t.Width <= size && t.Height <= size {
+		return t, nil
+	}
+	src, _, err := image.Decode(bytes.NewReader(t.Data))
+	if err != nil {
+		return nil, err
+	}
+	w, h := size, t.Height*size/t.Width
+	if t.Height > t.Width {
+		w, h = t.Width*size/t.Height, size
+	}
+	if w == 0 {
+		w = 1
+	}
+	if h == 0 {
+		h = 1
+	}
+	dst := shrinkImage(src, w, h)
+
+	buf := new(bytes.Buffer)
+	mediaType := mediaTypePNG
+	if t.MediaType == mediaTypeJPEG {
+		mediaType = mediaTypeJPEG
+		err = jpeg.Encode(buf, dst, &jpeg.Options{Quality: 90})
+	} else {
+		err = png.Encode(buf, dst)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &ThumbnailImage{
+		Data:      buf.Bytes(),
+		MediaType: mediaType,
+		Width:     w,
+		Height:    h,
+	}, nil
+}
+
+// shrinkImage scales down the image by averaging the source pixels covered by each destination pixel.
+func shrinkImage(src image.Image, w, h int) *image.NRGBA {
+	bounds := src.Bounds()
+	sw, sh := bounds.Dx(), bounds.Dy()
+	dst := image.NewNRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		y0 := bounds.Min.Y + y*sh/h
+		y1 := bounds.Min.Y + (y+1)*sh/h
+		if y1 <= y0 {
+			y1 = y0 + 1
+		}
+		for x := 0; x < w; x++ {
+			x0 := bounds.Min.X + x*sw/w
+			x1 := bounds.Min.X + (x+1)*sw/w
+			if x1 <= x0 {
+				x1 = x0 + 1
+			}
+			var r, g, b, a, n uint64
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					c := color.NRGBA64Model.Convert(src.At(sx, sy)).(color.NRGBA64)
+					// weight the colors by the alpha not to darken the transparent edges
+					r += uint64(c.R) * uint64(c.A)
+					g += uint64(c.G) * uint64(c.A)
+					b += uint64(c.B) * uint64(c.A)
+					a += uint64(c.A)
+					n++
+				}
+			}
+			var pixel color.NRGBA
+			if a > 0 {
+				pixel = color.NRGBA{
+					R: uint8(r / a >> 8),
+					G: uint8(g / a >> 8),
+					B: uint8(b / a >> 8),
+					A: uint8(a / n >> 8),
+				}
+			}
+			dst.SetNRGBA(x, y, pixel)
+		}
+	}
+	return dst
+}
+
+// newThumbnailRequestBody creates the multipart body which has the image as the file part with its content type.
+func newThumbnailRequestBody(img *ThumbnailImage) (requestBody, error) {
+	buf := new(bytes.Buffer)
+	writer := multipart.NewWriter(buf)
+	header := textproto.MIMEHeader{}
+	header.Set("Content-Disposition", fmt.Sprintf(`form-data; name="file"; filename="thumbnail%s"`, ThumbnailExtension(img.MediaType)))
+	header.Set("Content-Type", img.MediaType)
+	w, err := writer.CreatePart(header)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := w.Write(img.Data); err != nil {
+		return nil, err
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+	return newBinaryRequestBody(buf.Bytes(), writer.FormDataContentType()), nil
+}
+
+// mediaTypeWriter is the io.Writer which receives the media type of the response.
+type mediaTypeWriter struct {
+	io.Writer
+	mediaType string
+}
+
+// downloadThumbnail downloads the thumbnail to the writer and returns its media type.
+// The media type is detected from the content if the server does not tell it.
+func (c *Client) downloadThumbnail(path string, scope string, thumbnail io.Writer) (string, error) {
+	buf := new(bytes.Buffer)
+	w := &mediaTypeWriter{Writer: buf}
+	if err := c.get(path, scope, w); err != nil {
+		return "", err
+	}
+	mediaType, _, _ := mime.ParseMediaType(w.mediaType)
+	if ThumbnailExtension(mediaType) == "" {
+		mediaType = detectThumbnailMediaType(buf.Bytes())
+	}
+	if _, err := io.Copy(thumbnail, buf); err != nil {
+		return "", err
+	}
+	return mediaType, nil
+}
@@ -0,0 +1,418 @@
+package wso2am
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime/multipart"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+type (
+	// WSDL is a WSDL 1.1 document of the SOAP service.
+	WSDL struct {
+		// Source is the file path or the URL of the WSDL.
+		Source          string
+		Data            []byte
+		Name            string
+		TargetNamespace string
+		Services        []WSDLService
+		Operations      []WSDLOperation
+	}
+	WSDLService struct {
+		Name  string
+		Ports []WSDLPort
+	}
+	WSDLPort struct {
+		Name    string
+		Binding string
+		Address string
+	}
+	WSDLOperation struct {
+		Name          string
+		SOAPAction    string
+		Documentation string
+	}
+	// WSDLImplementationType is the way the gateway exposes the SOAP service.
+	WSDLImplementationType string
+)
+
+const (
+	// WSDLImplementationSOAP passes the SOAP messages through to the backend.
+	WSDLImplementationSOAP WSDLImplementationType = "SOAP"
+	// WSDLImplementationSOAPToREST exposes each SOAP operation as a REST resource.
+	WSDLImplementationSOAPToREST WSDLImplementationType = "SOAPTOREST"
+
+	wsdlNamespace   = "http://schemas.xmlsoap.org/wsdl/"
+	wsdl2Namespace  = "http://www.w3.org/ns/wsdl"
+	soap11Namespace = "http://schemas.xmlsoap.org/wsdl/soap/"
+	soap12Namespace = "http://schemas.xmlsoap.org/wsdl/soap12/"
+)
+
+type (
+	wsdlDefinitions struct {
+		XMLName         xml.Name          `xml:"definitions"`
+		Name            string            `xml:"name,attr"`
+		TargetNamespace string            `xml:"targetNamespace,attr"`
+		PortTypes       []wsdlPortType    `xml:"portType"`
+		Bindings        []wsdlBinding     `xml:"binding"`
+		Services        []wsdlServiceElem `xml:"service"`
+	}
+	wsdlPortType struct {
+		Name       string `xml:"name,attr"`
+		Operations []struct {
+			Name          string `xml:"name,attr"`
+			Documentation string `xml:"documentation"`
+		} `xml:"operation"`
+	}
+	wsdlBinding struct {
+		Name       string `xml:"name,attr"`
+		Type       string `xml:"type,attr"`
+		Operations []struct {
+			Name      string `xml:"name,attr"`
+			Operation []struct {
+				XMLName    xml.Name
+				SOAPAction string `xml:"soapAction,attr"`
+			} `xml:"operation"`
+		} `xml:"operation"`
+	}
+	wsdlServiceElem struct {
+		Name  string `xml:"name,attr"`
+		Ports []struct {
+			Name    string `xml:"name,attr"`
+			Binding string `xml:"binding,attr"`
+			Address []struct {
+				XMLName  xml.Name
+				Location string `xml:"location,attr"`
+			} `xml:"address"`
+		} `xml:"port"`
+	}
+)
+
+// wsdlHTTPClient downloads the WSDLs.  The timeout keeps an unresponsive server from blocking the command.
+var wsdlHTTPClient = &http.Client{Timeout: 30 * time.Second}
+
+// LoadWSDL loads the WSDL from the file or the http(s) URL, and validates it.
+func LoadWSDL(fileOrURL string) (*WSDL, error) {
+	var data []byte
+	if isURL(fileOrURL) {
+		resp, err := wsdlHTTPClient.Get(fileOrURL)
+		if err != nil {
+			return nil, err
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("failed to get the WSDL %s: %s", fileOrURL, resp.Status)
+		}
+		if data, err = ioutil.ReadAll(resp.Body); err != nil {
+			return nil, err
+		}
+	} else {
+		d, err := ioutil.ReadFile(fileOrURL)
+		if err != nil {
+			return nil, err
+		}
+		data = d
+	}
+	w, err := ParseWSDL(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid WSDL %s: %v", fileOrURL, err)
+	}
+	w.Source = fileOrURL
+	return w, nil
+}
+
+func isURL(s string) bool {
+	return strings.HasPrefix(s, "http://") || strings.HasPrefix(s, "https://")
+}
+
+// ParseWSDL parses and validates the WSDL 1.1 document.
+// The document must define at least one operation, and the ports must refer to the defined bindings.
+func ParseWSDL(data []byte) (*WSDL, error) {
+	var root struct {
+		XMLName xml.Name
+	}
+	if err := xml.Unmarshal(data, &root); err != nil {
+		return nil, err
+	}
+	switch {
+	case root.XMLName.Space == wsdl2Namespace:
+		return nil, errors.New("WSDL 2.0 is not supported")
+	case root.XMLName.Local != "definitions" || root.XMLName.Space != wsdlNamespace:
+		return nil, fmt.Errorf("root element must be {%s}definitions: {%s}%s", wsdlNamespace, root.XMLName.Space, root.XMLName.Local)
+	}
+	var defs wsdlDefinitions
+	if err := xml.Unmarshal(data, &defs); err != nil {
+		return nil, err
+	}
+
+	w := &WSDL{
+		Data:            data,
+		Name:            defs.Name,
+		TargetNamespace: defs.TargetNamespace,
+	}
+	portTypes := map[string]wsdlPortType{}
+	for _, pt := range defs.PortTypes {
+		portTypes[pt.Name] = pt
+	}
+	bindings := map[string]wsdlBinding{}
+	for _, b := range defs.Bindings {
+		if _, ok := portTypes[localName(b.Type)]; !ok {
+			return nil, fmt.Errorf("binding %s refers to the undefined portType %s", b.Name, b.Type)
+		}
+		bindings[b.Name] = b
+	}
+
+	usedBindings := []string{}
+	for _, s := range defs.Services {
+		service := WSDLService{Name: s.Name}
+		for _, p := range s.Ports {
+			if _, ok := bindings[localName(p.Binding)]; !ok {
+				return nil, fmt.Errorf("port %s of service %s refers to the undefined binding %s", p.Name, s.Name, p.Binding)
+			}
+			port := WSDLPort{Name: p.Name, Binding: localName(p.Binding)}
+			for _, a := range p.Address {
+				if a.XMLName.Space == soap11Namespace || a.XMLName.Space == soap12Namespace {
+					port.Address = a.Location
+				}
+			}
+			if port.Address == "" {
+				return nil, fmt.Errorf("port %s of service %s has no SOAP address", p.Name, s.Name)
+			}
+			service.Ports = append(service.Ports, port)
+			if !containsString(usedBindings, port.Binding) {
+				usedBindings = append(usedBindings, port.Binding)
+			}
+		}
+		w.Services = append(w.Services, service)
+	}
+	if len(w.Services) == 0 {
+		return nil, errors.New("no service defined")
+	}
+
+	// collect the operations of the bindings which are exposed by the services
+	for _, name := range usedBindings {
+		b := bindings[name]
+		pt := portTypes[localName(b.Type)]
+		for _, op := range pt.Operations {
+			if w.operation(op.Name) != nil {
+				continue
+			}
+			operation := WSDLOperation{
+				Name:          op.Name,
+				Documentation: strings.TrimSpace(op.Documentation),
+			}
+			for _, bop := range b.Operations {
+				if bop.Name != op.Name {
+					continue
+				}
+				for _, soapOp := range bop.Operation {
+					if soapOp.XMLName.Space == soap11Namespace || soapOp.XMLName.Space == soap12Namespace {
+						operation.SOAPAction = soapOp.SOAPAction
+					}
+				}
+			}
+			w.Operations = append(w.Operations, operation)
+		}
+	}
+	if len(w.Operations) == 0 {
+		return nil, errors.New("no operation defined")
+	}
+	return w, nil
+}
+
+func (w *WSDL) operation(name string) *WSDLOperation {
+	for i, op := range w.Operations {
+		if op.Name == name {
+			return &w.Operations[i]
+		}
+	}
+	return nil
+}
+
+func localName(qname string) string {
+	if i := strings.LastIndex(qname, ":"); i >= 0 {
+		return qname[i+1:]
+	}
+	return qname
+}
+
+// EndpointURL returns the SOAP address of the first port.
+func (w *WSDL) EndpointURL() string {
+	for _, s := range w.Services {
+		for _, p := range s.Ports {
+			return p.Address
+		}
+	}
+	return ""
+}
+
+// ParseWSDLImplementationType parses the implementation type case-insensitively.
+func ParseWSDLImplementationType(s string) (WSDLImplementationType, error) {
+	for _, t := range []WSDLImplementationType{WSDLImplementationSOAP, WSDLImplementationSOAPToREST} {
+		if strings.EqualFold(string(t), s) {
+			return t, nil
+		}
+	}
+	return "", fmt.Errorf("unsupported implementation type: %s (available: %s, %s)", s, WSDLImplementationSOAP, WSDLImplementationSOAPToREST)
+}
+
+// APIDefinition generates the API definition of the resources.
+// The SOAP pass-through API has the single POST resource which accepts the SOAP envelopes,
+// and the SOAP-to-REST API has a POST resource for each operation.
+func (w *WSDL) APIDefinition(implType WSDLImplementationType) (APIDefinition, error) {
+	paths := map[string]interface{}{}
+	switch implType {
+	case WSDLImplementationSOAP:
+		actions := []string{}
+		for _, op := range w.Operations {
+			if op.SOAPAction != "" {
+				actions = append(actions, op.SOAPAction)
+			}
+		}
+		paths["/*"] = map[string]interface{}{
+			"post": map[string]interface{}{
+				"consumes": []interface{}{"text/xml", "application/soap+xml"},
+				"produces": []interface{}{"text/xml", "application/soap+xml"},
+				"parameters": []interface{}{
+					map[string]interface{}{
+						"name":     "SOAP Request",
+						"in":       "body",
+						"required": true,
+						"schema":   map[string]interface{}{"type": "string"},
+					},
+					map[string]interface{}{
+						"name":        "SOAPAction",
+						"in":          "header",
+						"required":    false,
+						"type":        "string",
+						"description": strings.Join(actions, ", "),
+					},
+				},
+				"responses": map[string]interface{}{
+					"200": map[string]interface{}{"description": "SOAP response"},
+				},
+			},
+		}
+	case WSDLImplementationSOAPToREST:
+		for _, op := range w.Operations {
+			paths["/"+op.Name] = map[string]interface{}{
+				"post": map[string]interface{}{
+					"operationId": op.Name,
+					"description": op.Documentation,
+					"consumes":    []interface{}{"application/json"},
+					"produces":    []interface{}{"application/json"},
+					"parameters": []interface{}{
+						map[string]interface{}{
+							"name":     "body",
+							"in":       "body",
+							"required": true,
+							"schema":   map[string]interface{}{"type": "object"},
+						},
+					},
+					"responses": map[string]interface{}{
+						"200": map[string]interface{}{"description": "OK"},
+					},
+				},
+			}
+		}
+	default:
+		return "", fmt.Errorf("unsupported implementation type: %s", implType)
+	}
+	title := w.Name
+	if title == "" {
+		title = w.Services[0].Name
+	}
+	return newAPIDefinition(map[string]interface{}{
+		"swagger": "2.0",
+		"info": map[string]interface{}{
+			"title":   title,
+			"version": "1.0.0",
+		},
+		"paths": paths,
+	})
+}
+
+// NewWSDLEndpointConfig creates the endpoint config of the "wsdl" endpoint type.
+// The sandbox URL is optional.
+func NewWSDLEndpointConfig(productionURL, sandboxURL string) *APIEndpointConfig {
+	config := &APIEndpointConfig{
+		Type:                "wsdl",
+		ProductionEndpoints: &APIEndpoint{URL: productionURL},
+	}
+	if sandboxURL != "" {
+		config.SandboxEndpoints = &APIEndpoint{URL: sandboxURL}
+	}
+	return config
+}
+
+// NewWSDLAPI creates the API backed by the SOAP service of the WSDL.
+// The resources are generated from the operations, and the endpoint is the SOAP address of the WSDL.
+// If the WSDL is loaded from a URL, the URL is set as the WSDL URI of the API.
+func (c *Client) NewWSDLAPI(w *WSDL, implType WSDLImplementationType) (*APIDetail, error) {
+	if err := c.checkWSDLSupported(w, implType); err != nil {
+		return nil, err
+	}
+	def, err := w.APIDefinition(implType)
+	if err != nil {
+		return nil, err
+	}
+	if def, _, err = c.ConvertAPIDefinition(def); err != nil {
+		return nil, err
+	}
+	api := c.NewAPI()
+	api.Definition = def
+	if isURL(w.Source) {
+		uri := w.Source
+		api.WSDLURI = &uri
+	}
+	api.SetEndpointConfig(NewWSDLEndpointConfig(w.EndpointURL(), ""))
+	return api, nil
+}
+
+// CreateWSDLAPI creates the API backed by the SOAP service of the WSDL.
+// Publisher API v1 or later imports the WSDL file itself, so the server can generate the mediation for SOAP-to-REST.
+// The older versions accept only the WSDL URI, so a local WSDL file and SOAP-to-REST are rejected.
+func (c *Client) CreateWSDLAPI(api *APIDetail, w *WSDL, implType WSDLImplementationType) (*APIDetail, error) {
+	if err := c.checkWSDLSupported(w, implType); err != nil {
+		return nil, err
+	}
+	if strings.HasPrefix(c.config.APIVersion, "v0.") {
+		return c.CreateAPI(api)
+	}
+	return c.importAPIV1("apis/import-wsdl", api, func(writer *multipart.Writer) error {
+		if isURL(w.Source) {
+			if err := writer.WriteField("url", w.Source); err != nil {
+				return err
+			}
+		} else {
+			part, err := writer.CreateFormFile("file", filepath.Base(w.Source))
+			if err != nil {
+				return err
+			}
+			if _, err := io.Copy(part, bytes.NewReader(w.Data)); err != nil {
+				return err
+			}
+		}
+		return writer.WriteField("implementationType", string(implType))
+	})
+}
+
+// checkWSDLSupported rejects what the publisher API v0.x cannot create:
+// SOAP-to-REST, which would create the REST resources without the mediation converting them to SOAP,
+// and a local WSDL file, which cannot be attached to the API because only the WSDL URI is accepted.
+func (c *Client) checkWSDLSupported(w *WSDL, implType WSDLImplementationType) error {
+	if implType == WSDLImplementationSOAPToREST && strings.HasPrefix(c.config.APIVersion, "v0.") {
+		return fmt.Errorf("%s is not supported by the publisher API %s; use v1 or later, or %s", implType, c.config.APIVersion, WSDLImplementationSOAP)
+	}
+	if !isURL(w.Source) && strings.HasPrefix(c.config.APIVersion, "v0.") {
+		return fmt.Errorf("a local WSDL file cannot be attached to the API by the publisher API %s; use the URL of the WSDL, or v1 or later", c.config.APIVersion)
+	}
+	return nil
+}